@@ -0,0 +1,173 @@
+package is
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrBadHost = errors.New("bad host")
+	ErrBadPort = errors.New("bad port")
+)
+
+// Linux 下 sockaddr_un.sun_path 为 108 字节，需要保留一个字节给结尾的 NUL
+const maxUnixPathLength = 107
+
+// toPort 将整数或字符串转换为端口号，无法转换时返回 -1
+func toPort(val any) int {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := rv.Int(); n >= 0 && n <= 65535 {
+			return int(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n := rv.Uint(); n <= 65535 {
+			return int(n)
+		}
+	case reflect.String:
+		if n, err := strconv.ParseUint(rv.String(), 10, 16); err == nil {
+			return int(n)
+		}
+	}
+	return -1
+}
+
+// Port 判断给出的值是否为有效的端口号（1-65535）
+func Port[T any](t T) bool {
+	return toPort(t) > 0
+}
+
+// PrivilegedPort 判断给出的值是否为特权端口（1-1023），
+// 在类 Unix 系统中监听这些端口通常需要 root 权限。
+func PrivilegedPort[T any](t T) bool {
+	n := toPort(t)
+	return n > 0 && n < 1024
+}
+
+// UnprivilegedPort 判断给出的值是否为非特权端口（1024-65535）
+func UnprivilegedPort[T any](t T) bool {
+	return toPort(t) >= 1024
+}
+
+// EphemeralPort 判断给出的值是否处于 IANA 建议的动态端口范围（49152-65535）
+func EphemeralPort[T any](t T) bool {
+	return toPort(t) >= 49152
+}
+
+// Hostname 判断给出的字符串是否为符合 RFC 1123 规范的主机名，
+// 允许以 "." 结尾的完全限定域名。最后一个标签不能全部由数字组成，
+// 以免将 "999.999.999.999" 这类无效的 IPv4 地址当作主机名。
+func Hostname(str string) bool {
+	str = strings.TrimSuffix(str, ".")
+	if len(str) == 0 || len(str) > 253 {
+		return false
+	}
+	labels := strings.Split(str, ".")
+	if Number(labels[len(labels)-1]) {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParseHostPort 解析形如 "example.com:80"、"10.0.0.1:80" 或 "[::1]:80" 的地址，
+// 主机部分必须为 IP 地址或主机名，IPv6 地址必须使用方括号。
+func ParseHostPort(str string) (host string, port int, err error) {
+	host, port, err = splitHostPort(str)
+	if err != nil {
+		return
+	}
+	if host == "" {
+		return "", 0, ErrBadHost
+	}
+	if port == 0 {
+		return "", 0, ErrBadPort
+	}
+	return
+}
+
+func splitHostPort(str string) (host string, port int, err error) {
+	h, p, err := net.SplitHostPort(str)
+	if err != nil {
+		return "", 0, err
+	}
+	if port = toPort(p); port < 0 {
+		return "", 0, ErrBadPort
+	}
+	if strings.HasPrefix(str, "[") {
+		if !IPv6(h) {
+			return "", 0, ErrBadHost
+		}
+	} else if h != "" && !IPv4(h) && !Hostname(h) {
+		return "", 0, ErrBadHost
+	}
+	return h, port, nil
+}
+
+// HostPort 判断给出的字符串是否为有效的 "主机:端口" 地址
+func HostPort(str string) bool {
+	_, _, err := ParseHostPort(str)
+	return err == nil
+}
+
+// ListenAddr 判断给出的字符串是否为有效的监听地址，
+// 与 HostPort 相比允许省略主机（如 ":8080"）以及使用 0 端口由系统分配。
+func ListenAddr(str string) bool {
+	_, _, err := splitHostPort(str)
+	return err == nil
+}
+
+// UnixSocket 判断给出的字符串是否为形如 "unix:/run/app.sock" 的 Unix 套接字地址，
+// 同时接受 "unix:///run/app.sock" 写法以及 "unix:@name" 形式的抽象套接字。
+func UnixSocket(str string) bool {
+	path, ok := strings.CutPrefix(str, "unix:")
+	if !ok {
+		return false
+	}
+	if strings.HasPrefix(path, "//") {
+		path = path[2:]
+		if !strings.HasPrefix(path, "/") {
+			return false
+		}
+	}
+	if len(path) == 0 || len(path) > maxUnixPathLength {
+		return false
+	}
+	return !strings.ContainsRune(path, 0)
+}
+
+// SocketAddr 判断给出的字符串是否为 "主机:端口" 地址或 Unix 套接字地址
+func SocketAddr(str string) bool {
+	return UnixSocket(str) || HostPort(str)
+}
+
+// SocketAddrList 判断以 sep 分隔的字符串中的每一项是否都是有效的套接字地址，
+// 各项两端的空白会被忽略，空字符串或空项视为无效。
+func SocketAddrList(str, sep string) bool {
+	if str == "" {
+		return false
+	}
+	for _, item := range strings.Split(str, sep) {
+		if !SocketAddr(strings.TrimSpace(item)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package is
+
+import "testing"
+
+func TestHostname(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"example.com", true},
+		{"example.com.", true},
+		{"localhost", true},
+		{"1password.com", true},
+		{"a-b.example", true},
+		{"", false},
+		{"-a.example", false},
+		{"a..example", false},
+		{"999.999.999.999", false},
+		{"1.2.3", false},
+		{"2130706433", false},
+	}
+	for _, tt := range tests {
+		if got := Hostname(tt.host); got != tt.want {
+			t.Errorf("Hostname(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"example.com:80", true},
+		{"10.0.0.1:80", true},
+		{"[::1]:443", true},
+		{"999.999.999.999:80", false},
+		{"256.0.0.1:80", false},
+		{"example.com:0", false},
+		{":80", false},
+	}
+	for _, tt := range tests {
+		if got := HostPort(tt.addr); got != tt.want {
+			t.Errorf("HostPort(%q) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package is
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// calverTokens 是 https://calver.org 定义的格式标记及其对应的正则表达式
+var calverTokens = map[string]string{
+	"YYYY":     `[1-9]\d{3}`,
+	"YY":       `0|[1-9]\d{0,2}`,
+	"0Y":       `\d{2,3}`,
+	"MM":       `[1-9]|1[0-2]`,
+	"0M":       `0[1-9]|1[0-2]`,
+	"WW":       `[1-9]|[1-4]\d|5[0-3]`,
+	"0W":       `0[1-9]|[1-4]\d|5[0-3]`,
+	"DD":       `[1-9]|[12]\d|3[01]`,
+	"0D":       `0[1-9]|[12]\d|3[01]`,
+	"MAJOR":    `0|[1-9]\d*`,
+	"MINOR":    `0|[1-9]\d*`,
+	"MICRO":    `0|[1-9]\d*`,
+	"MODIFIER": `[0-9A-Za-z]+(?:[.-][0-9A-Za-z]+)*`,
+}
+
+var (
+	calverTokenRegex = regexp.MustCompile(`YYYY|YY|0Y|MM|0M|WW|0W|DD|0D|MAJOR|MINOR|MICRO|MODIFIER`)
+	calverFormats    sync.Map // map[string]*calverFormat
+)
+
+type calverFormat struct {
+	regex  *regexp.Regexp
+	tokens []string
+}
+
+// CalendarVersion 是按照日历版本（CalVer）格式解析的版本号
+type CalendarVersion struct {
+	// Year 完整的年份，"YY" 与 "0Y" 格式表示自 2000 年起的年数
+	Year  int
+	Month int
+	Week  int
+	Day   int
+	Major uint64
+	Minor uint64
+	Micro uint64
+	// Modifier 版本号末尾的修饰部分，如 "2024.10.1-beta" 中的 "beta"
+	Modifier string
+
+	// 按格式中出现的顺序保存各部分的数值，用于比较
+	values []uint64
+}
+
+// ParseCalVer 按照给出的格式解析日历版本号，格式由以下标记和分隔符（"."、"-"、"_"）组成：
+//
+//	YYYY 完整年份：2006、2016
+//	YY   短年份：6、16、106
+//	0Y   补零的短年份：06、16、106
+//	MM   月份：1、2、11
+//	0M   补零的月份：01、02、11
+//	WW   周：1、33、52
+//	0W   补零的周：01、33、52
+//	DD   日：1、9、31
+//	0D   补零的日：01、09、31
+//	MAJOR、MINOR、MICRO 语义化的版本号部分
+//	MODIFIER 修饰部分，如 "beta"、"rc.1"，只能出现在格式末尾
+//
+// 例如 "YYYY.0M.0D"、"YY.MM.MICRO"、"YYYY.MINOR.MICRO-MODIFIER"，
+// 同时包含年、月、日时会检查日期是否有效。
+func ParseCalVer(s, format string) (CalendarVersion, error) {
+	f, err := compileCalVerFormat(format)
+	if err != nil {
+		return CalendarVersion{}, err
+	}
+	m := f.regex.FindStringSubmatch(s)
+	if m == nil {
+		return CalendarVersion{}, ErrBadVersion
+	}
+	var v CalendarVersion
+	for i, token := range f.tokens {
+		part := m[i+1]
+		if token == "MODIFIER" {
+			v.Modifier = part
+			continue
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return CalendarVersion{}, ErrBadVersion
+		}
+		v.values = append(v.values, n)
+		switch token {
+		case "YYYY":
+			v.Year = int(n)
+		case "YY", "0Y":
+			v.Year = 2000 + int(n)
+		case "MM", "0M":
+			v.Month = int(n)
+		case "WW", "0W":
+			v.Week = int(n)
+		case "DD", "0D":
+			v.Day = int(n)
+		case "MAJOR":
+			v.Major = n
+		case "MINOR":
+			v.Minor = n
+		case "MICRO":
+			v.Micro = n
+		}
+	}
+	if v.Year > 0 && v.Month > 0 && v.Day > 0 {
+		t := time.Date(v.Year, time.Month(v.Month), v.Day, 0, 0, 0, 0, time.UTC)
+		if t.Day() != v.Day {
+			return CalendarVersion{}, ErrBadVersion
+		}
+	}
+	return v, nil
+}
+
+// CalVer 判断给出的字符串是否为符合格式的日历版本号
+func CalVer(s, format string) bool {
+	_, err := ParseCalVer(s, format)
+	return err == nil
+}
+
+// Compare 按照格式中各部分出现的顺序依次比较两个版本，
+// 数值都相同时没有修饰部分的版本更大，否则按字符串比较修饰部分。
+// 只有使用相同格式解析的版本才能相互比较。
+func (v CalendarVersion) Compare(o CalendarVersion) int {
+	for i := 0; i < len(v.values) && i < len(o.values); i++ {
+		if c := compareUint(v.values[i], o.values[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case v.Modifier == o.Modifier:
+		return 0
+	case v.Modifier == "":
+		return 1
+	case o.Modifier == "":
+		return -1
+	}
+	return strings.Compare(v.Modifier, o.Modifier)
+}
+
+func compileCalVerFormat(format string) (*calverFormat, error) {
+	if f, ok := calverFormats.Load(format); ok {
+		return f.(*calverFormat), nil
+	}
+	f := &calverFormat{}
+	var b strings.Builder
+	b.WriteByte('^')
+	rest := format
+	for rest != "" {
+		loc := calverTokenRegex.FindStringIndex(rest)
+		if loc == nil || loc[0] != 0 {
+			return nil, ErrBadVersion
+		}
+		token := rest[:loc[1]]
+		if len(f.tokens) > 0 && f.tokens[len(f.tokens)-1] == "MODIFIER" {
+			return nil, ErrBadVersion
+		}
+		f.tokens = append(f.tokens, token)
+		b.WriteString("(" + calverTokens[token] + ")")
+		rest = rest[loc[1]:]
+		if rest == "" {
+			break
+		}
+		switch rest[0] {
+		case '.', '-', '_':
+			b.WriteString(regexp.QuoteMeta(rest[:1]))
+			rest = rest[1:]
+			if rest == "" {
+				return nil, ErrBadVersion
+			}
+		default:
+			return nil, ErrBadVersion
+		}
+	}
+	if len(f.tokens) == 0 {
+		return nil, ErrBadVersion
+	}
+	b.WriteByte('$')
+	f.regex = regexp.MustCompile(b.String())
+	calverFormats.Store(format, f)
+	return f, nil
+}
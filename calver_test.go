@@ -0,0 +1,87 @@
+package is
+
+import "testing"
+
+func TestParseCalVer(t *testing.T) {
+	tests := []struct {
+		s, format string
+		want      CalendarVersion // Year 为 0 且 Major 为 0 表示应当解析失败
+		ok        bool
+	}{
+		{"2024.02.29", "YYYY.0M.0D", CalendarVersion{Year: 2024, Month: 2, Day: 29}, true},
+		{"2023.02.29", "YYYY.0M.0D", CalendarVersion{}, false},
+		{"2024.04.31", "YYYY.0M.0D", CalendarVersion{}, false},
+		{"2024.2.3", "YYYY.0M.0D", CalendarVersion{}, false},
+		{"2024.2.3", "YYYY.MM.DD", CalendarVersion{Year: 2024, Month: 2, Day: 3}, true},
+		{"2024.13.1", "YYYY.MM.DD", CalendarVersion{}, false},
+		{"24.10.3", "YY.MM.MICRO", CalendarVersion{Year: 2024, Month: 10, Micro: 3}, true},
+		{"06.1", "0Y.MM", CalendarVersion{Year: 2006, Month: 1}, true},
+		{"6.1", "0Y.MM", CalendarVersion{}, false},
+		{"106.1", "YY.MM", CalendarVersion{Year: 2106, Month: 1}, true},
+		{"2024_07", "YYYY_0W", CalendarVersion{Year: 2024, Week: 7}, true},
+		{"2024_54", "YYYY_0W", CalendarVersion{}, false},
+		{"2024.1.0-beta.1", "YYYY.MINOR.MICRO-MODIFIER", CalendarVersion{Year: 2024, Minor: 1, Modifier: "beta.1"}, true},
+		{"2024.1.0", "YYYY.MINOR.MICRO-MODIFIER", CalendarVersion{}, false},
+		{"2024.01.0", "YYYY.MINOR.MICRO", CalendarVersion{}, false},
+		{"0999.1", "YYYY.MM", CalendarVersion{}, false},
+		{"3.2024.5", "MAJOR.YYYY.MINOR", CalendarVersion{Year: 2024, Major: 3, Minor: 5}, true},
+
+		// 无效的格式
+		{"2024.1", "YYYY.MODIFIER.MICRO", CalendarVersion{}, false},
+		{"2024..1", "YYYY..MM", CalendarVersion{}, false},
+		{"202401", "YYYYMM", CalendarVersion{}, false},
+		{"2024.1", "YYYY.MM.", CalendarVersion{}, false},
+		{"2024", "", CalendarVersion{}, false},
+		{"2024/1", "YYYY/MM", CalendarVersion{}, false},
+	}
+	for _, tt := range tests {
+		v, err := ParseCalVer(tt.s, tt.format)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("ParseCalVer(%q, %q) = %+v, want error", tt.s, tt.format, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseCalVer(%q, %q) = %v", tt.s, tt.format, err)
+			continue
+		}
+		v.values = nil
+		if v.Year != tt.want.Year || v.Month != tt.want.Month || v.Week != tt.want.Week || v.Day != tt.want.Day ||
+			v.Major != tt.want.Major || v.Minor != tt.want.Minor || v.Micro != tt.want.Micro || v.Modifier != tt.want.Modifier {
+			t.Errorf("ParseCalVer(%q, %q) = %+v, want %+v", tt.s, tt.format, v, tt.want)
+		}
+	}
+}
+
+func TestCalVerCompare(t *testing.T) {
+	const format = "YYYY.MM.MICRO-MODIFIER"
+	tests := []struct {
+		a, b   string
+		format string
+		want   int
+	}{
+		{"2024.10.1", "2024.9.5", "YYYY.MM.MICRO", 1},
+		{"2023.12.9", "2024.1.0", "YYYY.MM.MICRO", -1},
+		{"2024.1.2", "2024.1.2", "YYYY.MM.MICRO", 0},
+		{"2024.1.0-beta", "2024.1.0-rc", format, -1},
+		{"2024.1.0-rc", "2024.1.0-rc", format, 0},
+		{"2024.1.1-beta", "2024.1.0-rc", format, 1},
+	}
+	for _, tt := range tests {
+		a, err1 := ParseCalVer(tt.a, tt.format)
+		b, err2 := ParseCalVer(tt.b, tt.format)
+		if err1 != nil || err2 != nil {
+			t.Fatalf("ParseCalVer(%q, %q) = %v, %v", tt.a, tt.b, err1, err2)
+		}
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	// 没有修饰部分的版本大于同一版本的修饰版本
+	a, _ := ParseCalVer("2024.1.0", "YYYY.MM.MICRO")
+	b, _ := ParseCalVer("2024.1.0-rc", format)
+	if a.Compare(b) != 1 || b.Compare(a) != -1 {
+		t.Errorf("Compare(2024.1.0, 2024.1.0-rc) = %d, want 1", a.Compare(b))
+	}
+}
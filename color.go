@@ -0,0 +1,459 @@
+package is
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrBadColor = errors.New("color: invalid CSS color")
+
+// ColorSpace 表示 CSS 颜色所使用的颜色空间
+type ColorSpace string
+
+const (
+	ColorSpaceSRGB  ColorSpace = "srgb"
+	ColorSpaceHSL   ColorSpace = "hsl"
+	ColorSpaceHWB   ColorSpace = "hwb"
+	ColorSpaceLab   ColorSpace = "lab"
+	ColorSpaceLCH   ColorSpace = "lch"
+	ColorSpaceOklab ColorSpace = "oklab"
+	ColorSpaceOklch ColorSpace = "oklch"
+)
+
+// ParsedColor 是解析后的 CSS 颜色值。
+//
+// Components 的含义取决于颜色空间：
+//   - srgb：红、绿、蓝，取值 0 至 1
+//   - hsl：色相（度）、饱和度、亮度，后两者取值 0 至 100
+//   - hwb：色相（度）、白度、黑度，后两者取值 0 至 100
+//   - lab：亮度 L（0 至 100）、a、b
+//   - lch：亮度 L（0 至 100）、彩度 C、色相（度）
+//   - oklab：亮度 L（0 至 1）、a、b
+//   - oklch：亮度 L（0 至 1）、彩度 C、色相（度）
+//
+// 关键字 "none" 表示的分量按 0 处理。
+type ParsedColor struct {
+	Space      ColorSpace
+	Components [3]float64
+	// Alpha 不透明度，取值 0 至 1
+	Alpha float64
+	// CurrentColor 表示关键字 "currentColor"，其实际颜色取决于上下文
+	CurrentColor bool
+
+	function string // 函数名，十六进制与关键字形式为空
+	hasAlpha bool   // 是否显式给出了不透明度
+	clamped  bool   // 是否有分量超出其取值范围，这些分量在转换时会被截断
+}
+
+// ParseColor 按照 CSS Color Module Level 4 解析颜色值，支持：
+//   - 十六进制：#rgb、#rgba、#rrggbb、#rrggbbaa
+//   - 命名颜色、transparent 与 currentColor
+//   - rgb()、rgba()、hsl()、hsla() 的逗号分隔语法与空格分隔语法
+//   - hwb()、lab()、lch()、oklab()、oklch()
+//
+// 空格分隔语法以 "/" 分隔不透明度，分量可以使用 "none"；不透明度可以是数值或百分比。
+// 函数名与关键字不区分大小写，超出范围的分量（如 "rgb(300 0 0)"）按照规范在转换时截断，
+// 不会导致解析失败；需要拒绝这类值时使用 RGB、HSL、Color 等校验函数。
+func ParseColor(s string) (ParsedColor, error) {
+	if strings.HasPrefix(s, "#") {
+		return parseHexColor(s[1:])
+	}
+	name, args, ok := strings.Cut(s, "(")
+	if !ok {
+		return parseNamedColor(s)
+	}
+	if !strings.HasSuffix(args, ")") {
+		return ParsedColor{}, ErrBadColor
+	}
+	name = strings.ToLower(name)
+	args = args[:len(args)-1]
+	c := ParsedColor{function: name, Alpha: 1}
+	legacy := strings.Contains(args, ",")
+	var parts []string
+	if legacy {
+		// 逗号分隔的旧语法只适用于 rgb() 与 hsl()，且不允许 "none"
+		if name != "rgb" && name != "rgba" && name != "hsl" && name != "hsla" {
+			return ParsedColor{}, ErrBadColor
+		}
+		parts = strings.Split(args, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+			if strings.EqualFold(parts[i], "none") {
+				return ParsedColor{}, ErrBadColor
+			}
+		}
+		if len(parts) != 3 && len(parts) != 4 {
+			return ParsedColor{}, ErrBadColor
+		}
+	} else {
+		channels, alpha, hasAlpha := strings.Cut(args, "/")
+		parts = strings.Fields(channels)
+		if len(parts) != 3 {
+			return ParsedColor{}, ErrBadColor
+		}
+		if hasAlpha {
+			fields := strings.Fields(alpha)
+			if len(fields) != 1 {
+				return ParsedColor{}, ErrBadColor
+			}
+			parts = append(parts, fields[0])
+		}
+	}
+	if len(parts) == 4 {
+		a, ok := parseColorValue(parts[3], 1)
+		if !ok {
+			return ParsedColor{}, ErrBadColor
+		}
+		c.Alpha = clamp(a, 0, 1)
+		c.hasAlpha = true
+		c.clamped = a < 0 || a > 1
+	}
+	switch name {
+	case "rgb", "rgba":
+		c.Space = ColorSpaceSRGB
+		percent := strings.HasSuffix(parts[0], "%")
+		for i := 0; i < 3; i++ {
+			// 旧语法要求三个分量同为数值或同为百分比
+			if legacy && strings.HasSuffix(parts[i], "%") != percent {
+				return ParsedColor{}, ErrBadColor
+			}
+			v, ok := parseColorValue(parts[i], 255)
+			if !ok {
+				return ParsedColor{}, ErrBadColor
+			}
+			c.Components[i] = v / 255
+			c.clamped = c.clamped || v < 0 || v > 255
+		}
+	case "hsl", "hsla", "hwb":
+		c.Space = ColorSpaceHSL
+		if name == "hwb" {
+			c.Space = ColorSpaceHWB
+		}
+		h, ok := parseHue(parts[0])
+		if !ok {
+			return ParsedColor{}, ErrBadColor
+		}
+		c.Components[0] = h
+		for i := 1; i < 3; i++ {
+			// 旧语法要求饱和度与亮度使用百分比
+			if legacy && !strings.HasSuffix(parts[i], "%") {
+				return ParsedColor{}, ErrBadColor
+			}
+			v, ok := parseColorValue(parts[i], 100)
+			if !ok {
+				return ParsedColor{}, ErrBadColor
+			}
+			c.Components[i] = v
+			c.clamped = c.clamped || v < 0 || v > 100
+		}
+	case "lab", "oklab", "lch", "oklch":
+		// 百分比的参照值：L 为 100% 对应的值，C 为 a、b 或彩度 100% 对应的值
+		l, ab, chroma := 100.0, 125.0, 150.0
+		if strings.HasPrefix(name, "ok") {
+			l, ab, chroma = 1, 0.4, 0.4
+		}
+		v, ok := parseColorValue(parts[0], l)
+		if !ok {
+			return ParsedColor{}, ErrBadColor
+		}
+		c.Components[0] = clamp(v, 0, l)
+		c.clamped = c.clamped || v < 0 || v > l
+		if strings.HasSuffix(name, "lab") {
+			c.Space = ColorSpaceLab
+			if name == "oklab" {
+				c.Space = ColorSpaceOklab
+			}
+			for i := 1; i < 3; i++ {
+				if c.Components[i], ok = parseColorValue(parts[i], ab); !ok {
+					return ParsedColor{}, ErrBadColor
+				}
+			}
+		} else {
+			c.Space = ColorSpaceLCH
+			if name == "oklch" {
+				c.Space = ColorSpaceOklch
+			}
+			if v, ok = parseColorValue(parts[1], chroma); !ok {
+				return ParsedColor{}, ErrBadColor
+			}
+			c.Components[1] = math.Max(v, 0)
+			c.clamped = c.clamped || v < 0
+			if c.Components[2], ok = parseHue(parts[2]); !ok {
+				return ParsedColor{}, ErrBadColor
+			}
+		}
+	default:
+		return ParsedColor{}, ErrBadColor
+	}
+	return c, nil
+}
+
+// CSSColor 判断给出的字符串是否为 ParseColor 能够解析的 CSS 颜色值，
+// 与 Color 不同，超出范围的分量按照规范截断而不会被拒绝
+func CSSColor(s string) bool {
+	_, err := ParseColor(s)
+	return err == nil
+}
+
+func parseHexColor(s string) (ParsedColor, error) {
+	if len(s) != 3 && len(s) != 4 && len(s) != 6 && len(s) != 8 {
+		return ParsedColor{}, ErrBadColor
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return ParsedColor{}, ErrBadColor
+	}
+	var rgba [4]uint64
+	if len(s) <= 4 {
+		for i := len(s) - 1; i >= 0; i-- {
+			rgba[i] = (v & 0xf) * 0x11
+			v >>= 4
+		}
+	} else {
+		for i := len(s)/2 - 1; i >= 0; i-- {
+			rgba[i] = v & 0xff
+			v >>= 8
+		}
+	}
+	c := ParsedColor{Space: ColorSpaceSRGB, Alpha: 1}
+	for i := 0; i < 3; i++ {
+		c.Components[i] = float64(rgba[i]) / 255
+	}
+	if len(s) == 4 || len(s) == 8 {
+		c.Alpha = float64(rgba[3]) / 255
+		c.hasAlpha = true
+	}
+	return c, nil
+}
+
+func parseNamedColor(s string) (ParsedColor, error) {
+	name := strings.ToLower(s)
+	switch name {
+	case "transparent":
+		return ParsedColor{Space: ColorSpaceSRGB, hasAlpha: true}, nil
+	case "currentcolor":
+		return ParsedColor{Space: ColorSpaceSRGB, Alpha: 1, CurrentColor: true}, nil
+	}
+	rgb, ok := namedColors[name]
+	if !ok {
+		return ParsedColor{}, ErrBadColor
+	}
+	return ParsedColor{
+		Space:      ColorSpaceSRGB,
+		Components: [3]float64{float64(rgb>>16) / 255, float64(rgb>>8&0xff) / 255, float64(rgb&0xff) / 255},
+		Alpha:      1,
+	}, nil
+}
+
+// parseColorValue 解析数值、百分比或 "none"，百分比按 percent 对应 100% 换算
+func parseColorValue(s string, percent float64) (float64, bool) {
+	if strings.EqualFold(s, "none") {
+		return 0, true
+	}
+	if num, ok := strings.CutSuffix(s, "%"); ok {
+		v, ok := parseDecimal(num)
+		return v / 100 * percent, ok
+	}
+	return parseDecimal(s)
+}
+
+// parseHue 解析色相，支持不带单位的度数以及 deg、grad、rad、turn 单位，结果归一化到 [0, 360)
+func parseHue(s string) (float64, bool) {
+	if strings.EqualFold(s, "none") {
+		return 0, true
+	}
+	lower := strings.ToLower(s)
+	scale := 1.0
+	for _, unit := range []struct {
+		suffix string
+		scale  float64
+	}{{"deg", 1}, {"grad", 0.9}, {"rad", 180 / math.Pi}, {"turn", 360}} {
+		if num, ok := strings.CutSuffix(lower, unit.suffix); ok {
+			lower, scale = num, unit.scale
+			break
+		}
+	}
+	v, ok := parseDecimal(lower)
+	if !ok {
+		return 0, false
+	}
+	v = math.Mod(v*scale, 360)
+	if v < 0 {
+		v += 360
+	}
+	return v, true
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
+
+// SRGB 将颜色转换为 sRGB 颜色空间，返回 0 至 1 之间的红、绿、蓝分量与不透明度，
+// 超出 sRGB 色域的颜色会被截断。currentColor 没有确定的颜色，转换结果为黑色。
+func (c ParsedColor) SRGB() (r, g, b, alpha float64) {
+	var rgb [3]float64
+	x := c.Components
+	switch c.Space {
+	case ColorSpaceSRGB:
+		rgb = x
+	case ColorSpaceHSL:
+		rgb = hslToSRGB(x[0], x[1]/100, x[2]/100)
+	case ColorSpaceHWB:
+		rgb = hwbToSRGB(x[0], x[1]/100, x[2]/100)
+	case ColorSpaceLab:
+		rgb = labToSRGB(x[0], x[1], x[2])
+	case ColorSpaceLCH:
+		a, b := polarToCartesian(x[1], x[2])
+		rgb = labToSRGB(x[0], a, b)
+	case ColorSpaceOklab:
+		rgb = oklabToSRGB(x[0], x[1], x[2])
+	case ColorSpaceOklch:
+		a, b := polarToCartesian(x[1], x[2])
+		rgb = oklabToSRGB(x[0], a, b)
+	}
+	return clamp(rgb[0], 0, 1), clamp(rgb[1], 0, 1), clamp(rgb[2], 0, 1), clamp(c.Alpha, 0, 1)
+}
+
+// NRGBA 将颜色转换为 8 位的 sRGB 颜色（非预乘不透明度）
+func (c ParsedColor) NRGBA() color.NRGBA {
+	r, g, b, a := c.SRGB()
+	to8 := func(v float64) uint8 { return uint8(math.Round(v * 255)) }
+	return color.NRGBA{R: to8(r), G: to8(g), B: to8(b), A: to8(a)}
+}
+
+func hslToSRGB(h, s, l float64) [3]float64 {
+	s, l = clamp(s, 0, 1), clamp(l, 0, 1)
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+	}
+	return [3]float64{f(0), f(8), f(4)}
+}
+
+func hwbToSRGB(h, w, b float64) [3]float64 {
+	w, b = clamp(w, 0, 1), clamp(b, 0, 1)
+	if w+b >= 1 {
+		gray := w / (w + b)
+		return [3]float64{gray, gray, gray}
+	}
+	rgb := hslToSRGB(h, 1, 0.5)
+	for i := range rgb {
+		rgb[i] = rgb[i]*(1-w-b) + w
+	}
+	return rgb
+}
+
+func polarToCartesian(chroma, hue float64) (a, b float64) {
+	rad := hue * math.Pi / 180
+	return chroma * math.Cos(rad), chroma * math.Sin(rad)
+}
+
+// labToSRGB 将 CIE Lab（D50 白点）转换为 sRGB，白点使用 Bradford 变换适配到 D65
+func labToSRGB(l, a, b float64) [3]float64 {
+	const kappa, epsilon = 24389.0 / 27, 216.0 / 24389
+	fy := (l + 16) / 116
+	fx := a/500 + fy
+	fz := fy - b/200
+	inverse := func(f float64) float64 {
+		if f3 := f * f * f; f3 > epsilon {
+			return f3
+		}
+		return (116*f - 16) / kappa
+	}
+	y := l / kappa
+	if l > kappa*epsilon {
+		y = fy * fy * fy
+	}
+	xyz := [3]float64{inverse(fx) * 0.3457 / 0.3585, y, inverse(fz) * (1 - 0.3457 - 0.3585) / 0.3585}
+	xyz = mulMatrix3([3][3]float64{
+		{0.955473421488075, -0.02309845494876471, 0.06325924320057072},
+		{-0.0283697093338637, 1.0099953980813041, 0.021041441191917323},
+		{0.012314014864481998, -0.020507649298898964, 1.330365926242124},
+	}, xyz)
+	return linearToSRGB(mulMatrix3([3][3]float64{
+		{3.2409699419045226, -1.537383177570094, -0.4986107602930034},
+		{-0.9692436362808796, 1.8759675015077202, 0.04155505740717559},
+		{0.05563007969699366, -0.20397695888897652, 1.0569715142428786},
+	}, xyz))
+}
+
+func oklabToSRGB(l, a, b float64) [3]float64 {
+	lms := mulMatrix3([3][3]float64{
+		{1, 0.3963377774, 0.2158037573},
+		{1, -0.1055613458, -0.0638541728},
+		{1, -0.0894841775, -1.2914855480},
+	}, [3]float64{l, a, b})
+	for i, v := range lms {
+		lms[i] = v * v * v
+	}
+	return linearToSRGB(mulMatrix3([3][3]float64{
+		{4.0767416621, -3.3077115913, 0.2309699292},
+		{-1.2684380046, 2.6097574011, -0.3413193965},
+		{-0.0041960863, -0.7034186147, 1.7076147010},
+	}, lms))
+}
+
+func mulMatrix3(m [3][3]float64, v [3]float64) (out [3]float64) {
+	for i := range m {
+		out[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2]
+	}
+	return
+}
+
+// linearToSRGB 对线性 sRGB 分量应用 sRGB 传递函数
+func linearToSRGB(rgb [3]float64) [3]float64 {
+	for i, v := range rgb {
+		abs := math.Abs(v)
+		if abs <= 0.0031308 {
+			rgb[i] = 12.92 * v
+		} else {
+			rgb[i] = math.Copysign(1.055*math.Pow(abs, 1/2.4)-0.055, v)
+		}
+	}
+	return rgb
+}
+
+// namedColors 是 CSS 命名颜色与其 sRGB 值
+var namedColors = map[string]uint32{
+	"aliceblue": 0xf0f8ff, "antiquewhite": 0xfaebd7, "aqua": 0x00ffff, "aquamarine": 0x7fffd4,
+	"azure": 0xf0ffff, "beige": 0xf5f5dc, "bisque": 0xffe4c4, "black": 0x000000,
+	"blanchedalmond": 0xffebcd, "blue": 0x0000ff, "blueviolet": 0x8a2be2, "brown": 0xa52a2a,
+	"burlywood": 0xdeb887, "cadetblue": 0x5f9ea0, "chartreuse": 0x7fff00, "chocolate": 0xd2691e,
+	"coral": 0xff7f50, "cornflowerblue": 0x6495ed, "cornsilk": 0xfff8dc, "crimson": 0xdc143c,
+	"cyan": 0x00ffff, "darkblue": 0x00008b, "darkcyan": 0x008b8b, "darkgoldenrod": 0xb8860b,
+	"darkgray": 0xa9a9a9, "darkgreen": 0x006400, "darkgrey": 0xa9a9a9, "darkkhaki": 0xbdb76b,
+	"darkmagenta": 0x8b008b, "darkolivegreen": 0x556b2f, "darkorange": 0xff8c00, "darkorchid": 0x9932cc,
+	"darkred": 0x8b0000, "darksalmon": 0xe9967a, "darkseagreen": 0x8fbc8f, "darkslateblue": 0x483d8b,
+	"darkslategray": 0x2f4f4f, "darkslategrey": 0x2f4f4f, "darkturquoise": 0x00ced1, "darkviolet": 0x9400d3,
+	"deeppink": 0xff1493, "deepskyblue": 0x00bfff, "dimgray": 0x696969, "dimgrey": 0x696969,
+	"dodgerblue": 0x1e90ff, "firebrick": 0xb22222, "floralwhite": 0xfffaf0, "forestgreen": 0x228b22,
+	"fuchsia": 0xff00ff, "gainsboro": 0xdcdcdc, "ghostwhite": 0xf8f8ff, "gold": 0xffd700,
+	"goldenrod": 0xdaa520, "gray": 0x808080, "green": 0x008000, "greenyellow": 0xadff2f,
+	"grey": 0x808080, "honeydew": 0xf0fff0, "hotpink": 0xff69b4, "indianred": 0xcd5c5c,
+	"indigo": 0x4b0082, "ivory": 0xfffff0, "khaki": 0xf0e68c, "lavender": 0xe6e6fa,
+	"lavenderblush": 0xfff0f5, "lawngreen": 0x7cfc00, "lemonchiffon": 0xfffacd, "lightblue": 0xadd8e6,
+	"lightcoral": 0xf08080, "lightcyan": 0xe0ffff, "lightgoldenrodyellow": 0xfafad2, "lightgray": 0xd3d3d3,
+	"lightgreen": 0x90ee90, "lightgrey": 0xd3d3d3, "lightpink": 0xffb6c1, "lightsalmon": 0xffa07a,
+	"lightseagreen": 0x20b2aa, "lightskyblue": 0x87cefa, "lightslategray": 0x778899, "lightslategrey": 0x778899,
+	"lightsteelblue": 0xb0c4de, "lightyellow": 0xffffe0, "lime": 0x00ff00, "limegreen": 0x32cd32,
+	"linen": 0xfaf0e6, "magenta": 0xff00ff, "maroon": 0x800000, "mediumaquamarine": 0x66cdaa,
+	"mediumblue": 0x0000cd, "mediumorchid": 0xba55d3, "mediumpurple": 0x9370db, "mediumseagreen": 0x3cb371,
+	"mediumslateblue": 0x7b68ee, "mediumspringgreen": 0x00fa9a, "mediumturquoise": 0x48d1cc, "mediumvioletred": 0xc71585,
+	"midnightblue": 0x191970, "mintcream": 0xf5fffa, "mistyrose": 0xffe4e1, "moccasin": 0xffe4b5,
+	"navajowhite": 0xffdead, "navy": 0x000080, "oldlace": 0xfdf5e6, "olive": 0x808000,
+	"olivedrab": 0x6b8e23, "orange": 0xffa500, "orangered": 0xff4500, "orchid": 0xda70d6,
+	"palegoldenrod": 0xeee8aa, "palegreen": 0x98fb98, "paleturquoise": 0xafeeee, "palevioletred": 0xdb7093,
+	"papayawhip": 0xffefd5, "peachpuff": 0xffdab9, "peru": 0xcd853f, "pink": 0xffc0cb,
+	"plum": 0xdda0dd, "powderblue": 0xb0e0e6, "purple": 0x800080, "rebeccapurple": 0x663399,
+	"red": 0xff0000, "rosybrown": 0xbc8f8f, "royalblue": 0x4169e1, "saddlebrown": 0x8b4513,
+	"salmon": 0xfa8072, "sandybrown": 0xf4a460, "seagreen": 0x2e8b57, "seashell": 0xfff5ee,
+	"sienna": 0xa0522d, "silver": 0xc0c0c0, "skyblue": 0x87ceeb, "slateblue": 0x6a5acd,
+	"slategray": 0x708090, "slategrey": 0x708090, "snow": 0xfffafa, "springgreen": 0x00ff7f,
+	"steelblue": 0x4682b4, "tan": 0xd2b48c, "teal": 0x008080, "thistle": 0xd8bfd8,
+	"tomato": 0xff6347, "turquoise": 0x40e0d0, "violet": 0xee82ee, "wheat": 0xf5deb3,
+	"white": 0xffffff, "whitesmoke": 0xf5f5f5, "yellow": 0xffff00, "yellowgreen": 0x9acd32,
+}
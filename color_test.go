@@ -0,0 +1,170 @@
+package is
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		s          string
+		space      ColorSpace
+		components [3]float64
+		alpha      float64
+	}{
+		{"#f00", ColorSpaceSRGB, [3]float64{1, 0, 0}, 1},
+		{"#FF000080", ColorSpaceSRGB, [3]float64{1, 0, 0}, 128.0 / 255},
+		{"RebeccaPurple", ColorSpaceSRGB, [3]float64{0x66 / 255.0, 0x33 / 255.0, 0x99 / 255.0}, 1},
+		{"transparent", ColorSpaceSRGB, [3]float64{}, 0},
+		{"rgb(255, 0, 0)", ColorSpaceSRGB, [3]float64{1, 0, 0}, 1},
+		{"rgba(255,0,0,0.05)", ColorSpaceSRGB, [3]float64{1, 0, 0}, 0.05},
+		{"RGB(100% 0% 50% / 25%)", ColorSpaceSRGB, [3]float64{1, 0, 0.5}, 0.25},
+		{"rgb(none 255 none)", ColorSpaceSRGB, [3]float64{0, 1, 0}, 1},
+		{"rgb(255 0 0 / none)", ColorSpaceSRGB, [3]float64{1, 0, 0}, 0},
+		{"hsl(120, 100%, 50%)", ColorSpaceHSL, [3]float64{120, 100, 50}, 1},
+		{"hsl(-120deg 50% 50%)", ColorSpaceHSL, [3]float64{240, 50, 50}, 1},
+		{"hsl(0.5turn 50% 50%)", ColorSpaceHSL, [3]float64{180, 50, 50}, 1},
+		{"hsl(200grad 50% 50%)", ColorSpaceHSL, [3]float64{180, 50, 50}, 1},
+		{"hsl(none 0% 100%)", ColorSpaceHSL, [3]float64{0, 0, 100}, 1},
+		{"hwb(0 20% 30%)", ColorSpaceHWB, [3]float64{0, 20, 30}, 1},
+		{"lab(50% 40 -20)", ColorSpaceLab, [3]float64{50, 40, -20}, 1},
+		{"lch(50 100% 30)", ColorSpaceLCH, [3]float64{50, 150, 30}, 1},
+		{"oklab(50% -100% 0.1)", ColorSpaceOklab, [3]float64{0.5, -0.4, 0.1}, 1},
+		{"oklch(0.7 0.1 none / 0.5)", ColorSpaceOklch, [3]float64{0.7, 0.1, 0}, 0.5},
+
+		// 超出范围的分量可以解析，并在转换时截断
+		{"rgb(300 0 0)", ColorSpaceSRGB, [3]float64{300.0 / 255, 0, 0}, 1},
+		{"hsl(0, 150%, 50%)", ColorSpaceHSL, [3]float64{0, 150, 50}, 1},
+		{"rgb(0 0 0 / 1.5)", ColorSpaceSRGB, [3]float64{}, 1},
+		{"lab(150 0 0)", ColorSpaceLab, [3]float64{100, 0, 0}, 1},
+		{"oklch(0.5 -0.1 0)", ColorSpaceOklch, [3]float64{0.5, 0, 0}, 1},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.s)
+		if err != nil {
+			t.Errorf("ParseColor(%q) = %v", tt.s, err)
+			continue
+		}
+		if c.Space != tt.space || !approxColor(c.Components, tt.components, 1e-9) || math.Abs(c.Alpha-tt.alpha) > 1e-9 {
+			t.Errorf("ParseColor(%q) = %s %v %v, want %s %v %v", tt.s, c.Space, c.Components, c.Alpha, tt.space, tt.components, tt.alpha)
+		}
+	}
+
+	c, err := ParseColor("currentColor")
+	if err != nil || !c.CurrentColor || c.NRGBA() != (color.NRGBA{A: 255}) {
+		t.Errorf("ParseColor(currentColor) = %+v, %v", c, err)
+	}
+}
+
+func TestParseColorInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"#",
+		"#ff",
+		"#fffff",
+		"#ggg",
+		"notacolor",
+		"rgb(255, 0, 0",
+		"rgb(255, 0)",
+		"rgb(255, 0, 0, 1, 1)",
+		"rgb(255, 0%, 0)",
+		"rgb(none, 0, 0)",
+		"rgb(255 0 0 0)",
+		"rgb(255 0 0 / 1 1)",
+		"rgb(255 0 0 /)",
+		"hsl(120, 100, 50)",
+		"hsl(120foo 100% 50%)",
+		"hwb(0, 20%, 30%)",
+		"lab(50, 40, -20)",
+		"color(srgb 1 0 0)",
+		"rgb(from red r g b)",
+		"rgb(1e 0 0)",
+	}
+	for _, s := range tests {
+		if c, err := ParseColor(s); err == nil {
+			t.Errorf("ParseColor(%q) = %+v, want error", s, c)
+		}
+	}
+}
+
+func TestColorConversion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want color.NRGBA
+	}{
+		{"hsl(120 100% 25%)", color.NRGBA{0, 128, 0, 255}},
+		{"hwb(0 100% 100%)", color.NRGBA{128, 128, 128, 255}},
+		{"hwb(240 0% 0% / 50%)", color.NRGBA{0, 0, 255, 128}},
+		{"lab(100 0 0)", color.NRGBA{255, 255, 255, 255}},
+		{"lab(0 0 0)", color.NRGBA{0, 0, 0, 255}},
+		{"lab(54.29 80.8 69.89)", color.NRGBA{255, 0, 0, 255}},
+		{"lch(54.29 106.84 40.85)", color.NRGBA{255, 0, 0, 255}},
+		{"oklab(1 0 0)", color.NRGBA{255, 255, 255, 255}},
+		{"oklab(0.62796 0.22486 0.12585)", color.NRGBA{255, 0, 0, 255}},
+		{"oklch(0.62796 0.25768 29.234)", color.NRGBA{255, 0, 0, 255}},
+		{"oklab(0.45201 -0.03246 -0.31153)", color.NRGBA{0, 0, 255, 255}},
+		// 超出色域的颜色与超出范围的分量会被截断
+		{"rgb(300 -20 0)", color.NRGBA{255, 0, 0, 255}},
+		{"oklch(0.9 0.4 145)", color.NRGBA{0, 255, 0, 255}},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.s)
+		if err != nil {
+			t.Errorf("ParseColor(%q) = %v", tt.s, err)
+			continue
+		}
+		if got := c.NRGBA(); !approxNRGBA(got, tt.want) {
+			t.Errorf("ParseColor(%q).NRGBA() = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestColorRange(t *testing.T) {
+	tests := []struct {
+		s                       string
+		rgb, rgba, hsl, hsla, c bool
+	}{
+		{"rgb(255, 0, 0)", true, false, false, false, true},
+		{"rgb(300, 0, 0)", false, false, false, false, false},
+		{"rgb(-1 0 0)", false, false, false, false, false},
+		{"rgb(101% 0% 0%)", false, false, false, false, false},
+		{"rgba(255, 0, 0, 0.05)", false, true, false, false, true},
+		{"rgba(255, 0, 0, 0.50)", false, true, false, false, true},
+		{"rgba(255, 0, 0, 1.5)", false, false, false, false, false},
+		{"rgb(255 0 0 / 150%)", false, false, false, false, false},
+		{"hsl(0, 100%, 50%)", false, false, true, false, true},
+		{"hsl(0, 150%, 50%)", false, false, false, false, false},
+		{"hsl(0 50% -10%)", false, false, false, false, false},
+		{"hsl(720 50% 50%)", false, false, true, false, true},
+		{"hsla(0, 100%, 50%, 0.5)", false, false, false, true, true},
+		{"hwb(0 120% 0%)", false, false, false, false, false},
+		{"lab(101 0 0)", false, false, false, false, false},
+		{"oklch(0.5 -0.1 0)", false, false, false, false, false},
+		{"lab(50 200 -200)", false, false, false, false, true},
+		{"#ff0000", false, false, false, false, true},
+	}
+	for _, tt := range tests {
+		if RGB(tt.s) != tt.rgb || RGBA(tt.s) != tt.rgba || HSL(tt.s) != tt.hsl || HSLA(tt.s) != tt.hsla || Color(tt.s) != tt.c {
+			t.Errorf("%q: RGB %v RGBA %v HSL %v HSLA %v Color %v, want %v %v %v %v %v", tt.s,
+				RGB(tt.s), RGBA(tt.s), HSL(tt.s), HSLA(tt.s), Color(tt.s), tt.rgb, tt.rgba, tt.hsl, tt.hsla, tt.c)
+		}
+		if !CSSColor(tt.s) {
+			t.Errorf("CSSColor(%q) = false", tt.s)
+		}
+	}
+}
+
+func approxColor(a, b [3]float64, eps float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > eps {
+			return false
+		}
+	}
+	return true
+}
+
+func approxNRGBA(a, b color.NRGBA) bool {
+	near := func(x, y uint8) bool { return max(x, y)-min(x, y) <= 1 }
+	return near(a.R, b.R) && near(a.G, b.G) && near(a.B, b.B) && a.A == b.A
+}
@@ -0,0 +1,80 @@
+package is
+
+import "math"
+
+// WCAGLevel 表示 WCAG 2 的对比度等级
+type WCAGLevel int
+
+const (
+	WCAGAA  WCAGLevel = iota // 普通文本 4.5:1，大号文本 3:1
+	WCAGAAA                  // 普通文本 7:1，大号文本 4.5:1
+)
+
+// MinContrast 返回该等级要求的最小对比度，大号文本指不小于 18pt 或不小于 14pt 的粗体
+func (level WCAGLevel) MinContrast(largeText bool) float64 {
+	switch {
+	case level == WCAGAAA && !largeText:
+		return 7
+	case level == WCAGAAA || !largeText:
+		return 4.5
+	default:
+		return 3
+	}
+}
+
+// RelativeLuminance 按照 WCAG 2 的定义返回颜色的相对亮度，取值 0（黑）至 1（白），
+// 计算时忽略不透明度。
+func (c ParsedColor) RelativeLuminance() float64 {
+	r, g, b, _ := c.SRGB()
+	linear := func(v float64) float64 {
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// ContrastRatio 返回前景色与背景色之间的对比度，取值 1 至 21，颜色格式参见 ParseColor。
+//
+// 半透明的背景色先叠加在白色上，半透明的前景色再叠加在背景色上；
+// currentColor 没有确定的颜色，会返回 ErrBadColor。
+func ContrastRatio(foreground, background string) (float64, error) {
+	fg, err := ParseColor(foreground)
+	if err != nil {
+		return 0, err
+	}
+	bg, err := ParseColor(background)
+	if err != nil {
+		return 0, err
+	}
+	if fg.CurrentColor || bg.CurrentColor {
+		return 0, ErrBadColor
+	}
+	white := ParsedColor{Space: ColorSpaceSRGB, Components: [3]float64{1, 1, 1}, Alpha: 1}
+	bg = blendColor(bg, white)
+	fg = blendColor(fg, bg)
+	l1, l2 := fg.RelativeLuminance(), bg.RelativeLuminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05), nil
+}
+
+// blendColor 将 c 按其不透明度叠加在不透明的 base 上，返回不透明的 sRGB 颜色
+func blendColor(c, base ParsedColor) ParsedColor {
+	r, g, b, a := c.SRGB()
+	br, bg, bb, _ := base.SRGB()
+	return ParsedColor{
+		Space:      ColorSpaceSRGB,
+		Components: [3]float64{r*a + br*(1-a), g*a + bg*(1-a), b*a + bb*(1-a)},
+		Alpha:      1,
+	}
+}
+
+// WCAGContrast 判断前景色与背景色的对比度是否满足给出的 WCAG 等级，
+// largeText 表示文本为大号文本，颜色无效时返回 false。
+func WCAGContrast(foreground, background string, level WCAGLevel, largeText bool) bool {
+	ratio, err := ContrastRatio(foreground, background)
+	return err == nil && ratio >= level.MinContrast(largeText)
+}
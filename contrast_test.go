@@ -0,0 +1,76 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		fg, bg string
+		want   float64
+	}{
+		{"#000", "#fff", 21},
+		{"#fff", "#000", 21},
+		{"white", "white", 1},
+		{"#777", "#fff", 4.478},
+		{"#767676", "#fff", 4.542},
+		{"red", "white", 3.998},
+		// 半透明的前景色叠加在背景色上
+		{"rgba(0, 0, 0, 0.5)", "#fff", 3.977},
+		{"rgb(0 0 0 / 0%)", "#777", 1},
+		{"#00000080", "#000", 1},
+		// 半透明的背景色先叠加在白色上
+		{"#000", "transparent", 21},
+		{"#fff", "rgba(0, 0, 0, 0.5)", 3.977},
+		{"#000", "rgb(0 0 0 / 50%)", 5.281},
+		{"rgb(0 0 0 / 50%)", "rgb(0 0 0 / 50%)", 2.618},
+	}
+	for _, tt := range tests {
+		got, err := ContrastRatio(tt.fg, tt.bg)
+		if err != nil {
+			t.Errorf("ContrastRatio(%q, %q) = %v", tt.fg, tt.bg, err)
+		} else if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("ContrastRatio(%q, %q) = %.4f, want %.3f", tt.fg, tt.bg, got, tt.want)
+		}
+	}
+
+	for _, pair := range [][2]string{{"currentColor", "#fff"}, {"#000", "currentcolor"}, {"nope", "#fff"}, {"#000", "#ff"}} {
+		if _, err := ContrastRatio(pair[0], pair[1]); !errors.Is(err, ErrBadColor) {
+			t.Errorf("ContrastRatio(%q, %q) = %v, want ErrBadColor", pair[0], pair[1], err)
+		}
+	}
+}
+
+func TestWCAGContrast(t *testing.T) {
+	tests := []struct {
+		fg, bg    string
+		level     WCAGLevel
+		largeText bool
+		want      bool
+	}{
+		{"#000", "#fff", WCAGAAA, false, true},
+		{"#777", "#fff", WCAGAA, false, false},
+		{"#777", "#fff", WCAGAA, true, true},
+		{"#767676", "#fff", WCAGAA, false, true},
+		{"#767676", "#fff", WCAGAAA, false, false},
+		{"#767676", "#fff", WCAGAAA, true, true},
+		{"#595959", "#fff", WCAGAAA, false, true},
+		{"rgba(0, 0, 0, 0.5)", "#fff", WCAGAA, true, true},
+		{"rgba(0, 0, 0, 0.5)", "#fff", WCAGAA, false, false},
+		{"currentColor", "#fff", WCAGAA, true, false},
+	}
+	for _, tt := range tests {
+		if got := WCAGContrast(tt.fg, tt.bg, tt.level, tt.largeText); got != tt.want {
+			t.Errorf("WCAGContrast(%q, %q, %d, %v) = %v, want %v", tt.fg, tt.bg, tt.level, tt.largeText, got, tt.want)
+		}
+	}
+}
+
+func TestMinContrast(t *testing.T) {
+	if WCAGAA.MinContrast(false) != 4.5 || WCAGAA.MinContrast(true) != 3 ||
+		WCAGAAA.MinContrast(false) != 7 || WCAGAAA.MinContrast(true) != 4.5 {
+		t.Error("MinContrast does not match WCAG 2 thresholds")
+	}
+}
@@ -0,0 +1,162 @@
+package is
+
+import (
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrDataURISyntax    = errors.New("data uri: invalid syntax")
+	ErrDataURIMediaType = errors.New("data uri: media type not allowed")
+	ErrDataURITooLarge  = errors.New("data uri: payload too large")
+	ErrDataURIMismatch  = errors.New("data uri: content does not match media type")
+)
+
+// ParsedDataURI 是解析后的 data URI
+type ParsedDataURI struct {
+	// MediaType 小写的媒体类型，省略时为 "text/plain"
+	MediaType string
+	// Params 媒体类型参数，参数名为小写，省略媒体类型时包含 charset=US-ASCII
+	Params map[string]string
+	// Base64 表示数据是否使用 base64 编码
+	Base64 bool
+	// Data 解码后的数据
+	Data []byte
+}
+
+// DataURIOptions 定义 CheckDataURI 的校验策略
+type DataURIOptions struct {
+	// MediaTypes 允许的媒体类型，支持 "image/*" 这样的通配写法，为空时不限制
+	MediaTypes []string
+	// MaxSize 解码后数据的最大字节数，为 0 时不限制
+	MaxSize int
+	// Sniff 根据解码后的内容探测实际类型，并要求与声明的媒体类型一致，
+	// 探测使用 http.DetectContentType，只适用于其能够识别的类型（如 PNG、JPEG、GIF、WebP、PDF），
+	// 对 SVG 等文本格式不应开启。
+	Sniff bool
+}
+
+// ParseDataURI 按照 RFC 2397 解析 data URI，并解码其中的数据
+func ParseDataURI(s string) (*ParsedDataURI, error) {
+	return parseDataURI(s, 0)
+}
+
+func parseDataURI(s string, maxSize int) (*ParsedDataURI, error) {
+	if len(s) < 5 || !strings.EqualFold(s[:5], "data:") {
+		return nil, ErrDataURISyntax
+	}
+	header, payload, ok := strings.Cut(s[5:], ",")
+	if !ok {
+		return nil, ErrDataURISyntax
+	}
+	d := &ParsedDataURI{}
+	if h, found := cutSuffixFold(header, ";base64"); found {
+		header, d.Base64 = h, true
+	}
+	switch {
+	case header == "":
+		d.MediaType = "text/plain"
+		d.Params = map[string]string{"charset": "US-ASCII"}
+	default:
+		if strings.HasPrefix(header, ";") {
+			header = "text/plain" + header
+		}
+		mediaType, params, err := mime.ParseMediaType(header)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			return nil, ErrDataURISyntax
+		}
+		d.MediaType, d.Params = mediaType, params
+	}
+	if maxSize > 0 && estimateDataSize(payload, d.Base64) > maxSize {
+		return nil, ErrDataURITooLarge
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, ErrDataURISyntax
+	}
+	if d.Base64 {
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			if b, err = base64.RawStdEncoding.DecodeString(data); err != nil {
+				return nil, ErrDataURISyntax
+			}
+		}
+		d.Data = b
+	} else {
+		d.Data = []byte(data)
+	}
+	if maxSize > 0 && len(d.Data) > maxSize {
+		return nil, ErrDataURITooLarge
+	}
+	return d, nil
+}
+
+// CheckDataURI 按照给出的策略校验 data URI，并返回具体的失败原因
+func CheckDataURI(s string, opts DataURIOptions) error {
+	d, err := parseDataURI(s, opts.MaxSize)
+	if err != nil {
+		return err
+	}
+	if len(opts.MediaTypes) > 0 && !matchMediaType(d.MediaType, opts.MediaTypes) {
+		return ErrDataURIMediaType
+	}
+	if opts.Sniff {
+		sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(d.Data))
+		if sniffed != d.MediaType {
+			return ErrDataURIMismatch
+		}
+	}
+	return nil
+}
+
+// DataURI 判断给出的字符串是否为有效的 data URI
+func DataURI(s string) bool {
+	_, err := ParseDataURI(s)
+	return err == nil
+}
+
+// estimateDataSize 在解码前估算数据解码后的最小字节数，用于尽早拒绝过大的数据
+func estimateDataSize(payload string, isBase64 bool) int {
+	// 每个 "%XX" 只对应一个字节
+	n := len(payload) - 2*strings.Count(payload, "%")
+	if isBase64 {
+		// 结尾的填充字符（可能被编码为 "%3D"）不对应任何数据
+		for {
+			if rest, ok := strings.CutSuffix(payload, "="); ok {
+				payload = rest
+			} else if rest, ok := cutSuffixFold(payload, "%3d"); ok {
+				payload = rest
+			} else {
+				break
+			}
+			n--
+		}
+		return n * 3 / 4
+	}
+	return n
+}
+
+func matchMediaType(mediaType string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func cutSuffixFold(s, suffix string) (string, bool) {
+	if len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+		return s[:len(s)-len(suffix)], true
+	}
+	return s, false
+}
@@ -0,0 +1,115 @@
+package is
+
+import "testing"
+
+func TestCheckDataURIMaxSize(t *testing.T) {
+	tests := []struct {
+		uri     string
+		maxSize int
+		want    error
+	}{
+		{"data:;base64,AA==", 1, nil},
+		{"data:;base64,AA%3D%3D", 1, nil},
+		{"data:;base64,AA", 1, nil},
+		{"data:;base64,AAA=", 2, nil},
+		{"data:;base64,AAAA", 3, nil},
+		{"data:;base64,AAA=", 1, ErrDataURITooLarge},
+		{"data:;base64,AAAA", 2, ErrDataURITooLarge},
+		{"data:;base64,AAAAAAAA", 5, ErrDataURITooLarge},
+		{"data:,a", 1, nil},
+		{"data:,%41", 1, nil},
+		{"data:,ab", 1, ErrDataURITooLarge},
+		{"data:,%41%42", 1, ErrDataURITooLarge},
+	}
+	for _, tt := range tests {
+		if err := CheckDataURI(tt.uri, DataURIOptions{MaxSize: tt.maxSize}); err != tt.want {
+			t.Errorf("CheckDataURI(%q, MaxSize: %d) = %v, want %v", tt.uri, tt.maxSize, err, tt.want)
+		}
+	}
+}
+
+func TestParseDataURI(t *testing.T) {
+	tests := []struct {
+		uri       string
+		mediaType string
+		params    map[string]string
+		data      string
+	}{
+		{"data:,Hello%2C%20World%21", "text/plain", map[string]string{"charset": "US-ASCII"}, "Hello, World!"},
+		{"data:;charset=utf-8,%E4%BD%A0%E5%A5%BD", "text/plain", map[string]string{"charset": "utf-8"}, "你好"},
+		{"DATA:Text/HTML;Charset=UTF-8,<p>hi</p>", "text/html", map[string]string{"charset": "UTF-8"}, "<p>hi</p>"},
+		{"data:text/plain;base64,SGVsbG8=", "text/plain", map[string]string{}, "Hello"},
+		{"data:text/plain;BASE64,SGVsbG8", "text/plain", map[string]string{}, "Hello"},
+		{"data:text/plain;base64,SGVs%62G8=", "text/plain", map[string]string{}, "Hello"},
+		{"data:image/svg+xml,%3Csvg%2F%3E", "image/svg+xml", map[string]string{}, "<svg/>"},
+		{"data:,", "text/plain", map[string]string{"charset": "US-ASCII"}, ""},
+	}
+	for _, tt := range tests {
+		d, err := ParseDataURI(tt.uri)
+		if err != nil {
+			t.Errorf("ParseDataURI(%q) = %v", tt.uri, err)
+			continue
+		}
+		if d.MediaType != tt.mediaType || string(d.Data) != tt.data || len(d.Params) != len(tt.params) {
+			t.Errorf("ParseDataURI(%q) = %q %v %q, want %q %v %q", tt.uri, d.MediaType, d.Params, d.Data, tt.mediaType, tt.params, tt.data)
+			continue
+		}
+		for k, v := range tt.params {
+			if d.Params[k] != v {
+				t.Errorf("ParseDataURI(%q).Params[%q] = %q, want %q", tt.uri, k, d.Params[k], v)
+			}
+		}
+	}
+
+	malformed := []string{
+		"",
+		"data",
+		"data:text/plain",
+		"http://example.com/",
+		"data:text,hi",
+		"data:text/plain;charset,hi",
+		"data:/plain,hi",
+		"data:text/plain;base64,SGVsbG8*",
+		"data:text/plain;base64,S",
+		"data:,100%",
+		"data:,%zz",
+	}
+	for _, uri := range malformed {
+		if _, err := ParseDataURI(uri); err != ErrDataURISyntax {
+			t.Errorf("ParseDataURI(%q) = %v, want %v", uri, err, ErrDataURISyntax)
+		}
+	}
+}
+
+func TestCheckDataURIMediaTypes(t *testing.T) {
+	const (
+		png = "data:image/png;base64,iVBORw0KGgo="
+		gif = "data:image/gif;base64,R0lGODlh"
+	)
+	tests := []struct {
+		uri  string
+		opts DataURIOptions
+		want error
+	}{
+		{png, DataURIOptions{MediaTypes: []string{"image/png"}}, nil},
+		{png, DataURIOptions{MediaTypes: []string{"image/*"}}, nil},
+		{png, DataURIOptions{MediaTypes: []string{"IMAGE/PNG"}}, nil},
+		{png, DataURIOptions{MediaTypes: []string{"image/jpeg", "image/gif"}}, ErrDataURIMediaType},
+		{png, DataURIOptions{MediaTypes: []string{"text/*"}}, ErrDataURIMediaType},
+		{png, DataURIOptions{MediaTypes: []string{"image"}}, ErrDataURIMediaType},
+		{"data:text/plain;charset=utf-8,hi", DataURIOptions{MediaTypes: []string{"text/plain"}}, nil},
+		{"data:,hi", DataURIOptions{MediaTypes: []string{"text/plain"}}, nil},
+		{"data:image/svg+xml,<svg/>", DataURIOptions{MediaTypes: []string{"image/png"}}, ErrDataURIMediaType},
+
+		{png, DataURIOptions{Sniff: true}, nil},
+		{gif, DataURIOptions{Sniff: true}, nil},
+		{"data:image/png;base64,R0lGODlh", DataURIOptions{Sniff: true}, ErrDataURIMismatch},
+		{"data:image/jpeg;base64,iVBORw0KGgo=", DataURIOptions{Sniff: true, MediaTypes: []string{"image/*"}}, ErrDataURIMismatch},
+		{"data:image/png,<script>", DataURIOptions{Sniff: true}, ErrDataURIMismatch},
+	}
+	for _, tt := range tests {
+		if err := CheckDataURI(tt.uri, tt.opts); err != tt.want {
+			t.Errorf("CheckDataURI(%q, %+v) = %v, want %v", tt.uri, tt.opts, err, tt.want)
+		}
+	}
+}
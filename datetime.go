@@ -0,0 +1,163 @@
+package is
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 预置的日期时间格式，可以与 Go 的时间布局一起出现在 DatetimeOptions.Layouts 中
+const (
+	DatetimeRFC3339     = "rfc3339"     // 2006-01-02T15:04:05Z07:00，不允许小数秒
+	DatetimeRFC3339Nano = "rfc3339nano" // 同 rfc3339，允许小数秒
+	DatetimeDate        = "date"        // 2006-01-02
+	DatetimeDateTime    = "datetime"    // 2006-01-02 15:04:05
+	DatetimeISOWeek     = "isoweek"     // ISO 8601 周日期，如 2024-W05-3、2024W053、2024-W05
+	DatetimeUnix        = "unix"        // Unix 时间戳（秒）
+	DatetimeUnixMilli   = "unixmilli"   // Unix 时间戳（毫秒）
+)
+
+var (
+	ErrBadDatetime     = errors.New("datetime: invalid syntax")
+	ErrDatetimeBefore  = errors.New("datetime: too early")
+	ErrDatetimeAfter   = errors.New("datetime: too late")
+	ErrDatetimeFuture  = errors.New("datetime: in the future")
+	ErrDatetimeTooOld  = errors.New("datetime: too old")
+	ErrDatetimeWeekend = errors.New("datetime: not a weekday")
+
+	isoWeekRegex = regexp.MustCompile(`^(\d{4})(-?)W(\d{2})(?:(-?)([1-7]))?$`)
+	// unixTimestampRegex 匹配整数时间戳，不允许 "+" 号与小数
+	unixTimestampRegex = regexp.MustCompile(`^-?[0-9]+$`)
+)
+
+// DatetimeOptions 定义日期时间的格式与取值约束，零值只接受 RFC 3339 格式
+type DatetimeOptions struct {
+	// Layouts 依次尝试的格式，可以是 Go 的时间布局或预置的 Datetime* 格式，为空时使用 DatetimeRFC3339
+	Layouts []string
+	// Location 解析不含时区的格式时使用的时区，为空时使用 UTC
+	Location *time.Location
+	// After 要求不早于该时间，为零值时不限制
+	After time.Time
+	// Before 要求不晚于该时间，为零值时不限制
+	Before time.Time
+	// NotFuture 拒绝晚于当前时间的值
+	NotFuture bool
+	// WithinDays 要求位于最近 N 天内（不早于当前时间减去 N 天，且不晚于当前时间），为 0 时不限制
+	WithinDays int
+	// WeekdayOnly 拒绝周六与周日，按照值自身的时区判断，
+	// 不含时区的格式与 Unix 时间戳按照 Location 判断
+	WeekdayOnly bool
+	// Now 返回当前时间，为空时使用 time.Now
+	Now func() time.Time
+}
+
+// ParseDatetime 按照给出的格式依次尝试解析日期时间，返回第一个成功的结果，
+// 并检查取值约束。格式同时包含 DatetimeUnix 与 DatetimeUnixMilli 时，
+// 纯数字的值按照先出现的格式解析。
+func ParseDatetime(s string, opts DatetimeOptions) (time.Time, error) {
+	layouts := opts.Layouts
+	if len(layouts) == 0 {
+		layouts = []string{DatetimeRFC3339}
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	var t time.Time
+	var err error
+	for _, layout := range layouts {
+		if t, err = parseDatetimeLayout(s, layout, loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, ErrBadDatetime
+	}
+	if !opts.After.IsZero() && t.Before(opts.After) {
+		return t, ErrDatetimeBefore
+	}
+	if !opts.Before.IsZero() && t.After(opts.Before) {
+		return t, ErrDatetimeAfter
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	if opts.NotFuture || opts.WithinDays > 0 {
+		n := now()
+		if t.After(n) {
+			return t, ErrDatetimeFuture
+		}
+		if opts.WithinDays > 0 && t.Before(n.AddDate(0, 0, -opts.WithinDays)) {
+			return t, ErrDatetimeTooOld
+		}
+	}
+	if opts.WeekdayOnly && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return t, ErrDatetimeWeekend
+	}
+	return t, nil
+}
+
+func parseDatetimeLayout(s, layout string, loc *time.Location) (time.Time, error) {
+	switch layout {
+	case DatetimeRFC3339:
+		// time.Parse 在布局没有小数秒时也会接受小数秒
+		if strings.Contains(s, ".") {
+			return time.Time{}, ErrBadDatetime
+		}
+		return time.Parse(time.RFC3339, s)
+	case DatetimeRFC3339Nano:
+		return time.Parse(time.RFC3339Nano, s)
+	case DatetimeDate:
+		return time.ParseInLocation(time.DateOnly, s, loc)
+	case DatetimeDateTime:
+		return time.ParseInLocation(time.DateTime, s, loc)
+	case DatetimeISOWeek:
+		return parseISOWeek(s, loc)
+	case DatetimeUnix, DatetimeUnixMilli:
+		if !unixTimestampRegex.MatchString(s) {
+			return time.Time{}, ErrBadDatetime
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, ErrBadDatetime
+		}
+		if layout == DatetimeUnix {
+			return time.Unix(n, 0).In(loc), nil
+		}
+		return time.UnixMilli(n).In(loc), nil
+	}
+	return time.ParseInLocation(layout, s, loc)
+}
+
+// parseISOWeek 解析 ISO 8601 周日期，省略星期时为该周的星期一
+func parseISOWeek(s string, loc *time.Location) (time.Time, error) {
+	m := isoWeekRegex.FindStringSubmatch(s)
+	// 扩展格式与基本格式不能混用
+	if m == nil || (m[5] != "" && m[2] != m[4]) {
+		return time.Time{}, ErrBadDatetime
+	}
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[3])
+	day := 1
+	if m[5] != "" {
+		day, _ = strconv.Atoi(m[5])
+	}
+	// 12 月 28 日总是位于当年的最后一周
+	_, weeks := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	if week < 1 || week > weeks {
+		return time.Time{}, ErrBadDatetime
+	}
+	// 1 月 4 日总是位于当年的第一周
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	return monday.AddDate(0, 0, (week-1)*7+day-1), nil
+}
+
+// CheckDatetime 校验日期时间的格式与取值约束，参见 ParseDatetime
+func CheckDatetime(s string, opts DatetimeOptions) error {
+	_, err := ParseDatetime(s, opts)
+	return err
+}
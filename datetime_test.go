@@ -0,0 +1,189 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDatetimeLayouts(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	tests := []struct {
+		s      string
+		layout string
+		loc    *time.Location
+		want   string // RFC 3339 形式的结果，为空表示应当解析失败
+	}{
+		{"2024-01-05T10:00:00Z", DatetimeRFC3339, nil, "2024-01-05T10:00:00Z"},
+		{"2024-01-05T10:00:00+08:00", DatetimeRFC3339, nil, "2024-01-05T10:00:00+08:00"},
+		{"2024-01-05T10:00:00.5Z", DatetimeRFC3339, nil, ""},
+		{"2024-01-05T10:00:00", DatetimeRFC3339, nil, ""},
+		{"2024-01-05 10:00:00Z", DatetimeRFC3339, nil, ""},
+		{"2024-01-05T10:00:00.123456789Z", DatetimeRFC3339Nano, nil, "2024-01-05T10:00:00.123456789Z"},
+		{"2024-01-05T10:00:00Z", DatetimeRFC3339Nano, nil, "2024-01-05T10:00:00Z"},
+
+		{"2024-02-29", DatetimeDate, nil, "2024-02-29T00:00:00Z"},
+		{"2024-02-29", DatetimeDate, shanghai, "2024-02-29T00:00:00+08:00"},
+		{"2023-02-29", DatetimeDate, nil, ""},
+		{"2024-2-29", DatetimeDate, nil, ""},
+		{"2024-01-05 10:00:00", DatetimeDateTime, shanghai, "2024-01-05T10:00:00+08:00"},
+		{"2024-01-05T10:00:00", DatetimeDateTime, nil, ""},
+
+		{"1700000000", DatetimeUnix, nil, "2023-11-14T22:13:20Z"},
+		{"1700000000", DatetimeUnix, shanghai, "2023-11-15T06:13:20+08:00"},
+		{"-1", DatetimeUnix, nil, "1969-12-31T23:59:59Z"},
+		{"0", DatetimeUnix, nil, "1970-01-01T00:00:00Z"},
+		{"+1700000000", DatetimeUnix, nil, ""},
+		{"1700000000.5", DatetimeUnix, nil, ""},
+		{"1e9", DatetimeUnix, nil, ""},
+		{"", DatetimeUnix, nil, ""},
+		{"99999999999999999999", DatetimeUnix, nil, ""},
+		{"1700000000123", DatetimeUnixMilli, nil, "2023-11-14T22:13:20.123Z"},
+		{"+1700000000123", DatetimeUnixMilli, nil, ""},
+		{"-1000", DatetimeUnixMilli, nil, "1969-12-31T23:59:59Z"},
+
+		{"05/01/2024", "02/01/2006", nil, "2024-01-05T00:00:00Z"},
+		{"2024-01-05", "02/01/2006", nil, ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseDatetime(tt.s, DatetimeOptions{Layouts: []string{tt.layout}, Location: tt.loc})
+		if tt.want == "" {
+			if !errors.Is(err, ErrBadDatetime) {
+				t.Errorf("ParseDatetime(%q, %s) = %v, %v, want ErrBadDatetime", tt.s, tt.layout, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDatetime(%q, %s) = %v", tt.s, tt.layout, err)
+		} else if got.Format(time.RFC3339Nano) != tt.want {
+			t.Errorf("ParseDatetime(%q, %s) = %s, want %s", tt.s, tt.layout, got.Format(time.RFC3339Nano), tt.want)
+		}
+	}
+
+	// 零值只接受 RFC 3339
+	if err := CheckDatetime("2024-01-05", DatetimeOptions{}); !errors.Is(err, ErrBadDatetime) {
+		t.Errorf("CheckDatetime(date) with zero options = %v", err)
+	}
+	// 纯数字的值按照先出现的格式解析
+	opts := DatetimeOptions{Layouts: []string{DatetimeDate, DatetimeUnixMilli, DatetimeUnix}}
+	if got, _ := ParseDatetime("1700000000", opts); !got.Equal(time.UnixMilli(1700000000)) {
+		t.Errorf("ParseDatetime with unixmilli first = %v", got)
+	}
+	if got, _ := ParseDatetime("2024-01-05", opts); !got.Equal(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseDatetime with date first = %v", got)
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string // 为空表示应当解析失败
+	}{
+		{"2024-W05-3", "2024-01-31"},
+		{"2024W053", "2024-01-31"},
+		{"2024-W05", "2024-01-29"},
+		{"2024W05", "2024-01-29"},
+		{"2009-W01-1", "2008-12-29"},
+		{"2015-W53-7", "2016-01-03"},
+		// 2020 年有 53 周，2021 年只有 52 周
+		{"2020-W53", "2020-12-28"},
+		{"2020-W53-7", "2021-01-03"},
+		{"2021-W52-7", "2022-01-02"},
+		{"2021-W53", ""},
+		{"2021W531", ""},
+		{"2024-W00", ""},
+		{"2024-W05-0", ""},
+		{"2024-W05-8", ""},
+		// 扩展格式与基本格式不能混用
+		{"2024-W053", ""},
+		{"2024W05-3", ""},
+		{"2024-W5", ""},
+		{"24-W05", ""},
+		{"2024-w05", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseDatetime(tt.s, DatetimeOptions{Layouts: []string{DatetimeISOWeek}})
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseDatetime(%q, isoweek) = %v, want error", tt.s, got)
+			}
+			continue
+		}
+		if err != nil || got.Format(time.DateOnly) != tt.want {
+			t.Errorf("ParseDatetime(%q, isoweek) = %v, %v, want %s", tt.s, got, err, tt.want)
+			continue
+		}
+		// 结果的 ISO 周应与输入一致
+		year, week := got.ISOWeek()
+		if basic := strings.ReplaceAll(tt.s, "-", ""); fmt.Sprintf("%dW%02d", year, week) != basic[:7] {
+			t.Errorf("ParseDatetime(%q, isoweek).ISOWeek() = %d-W%02d", tt.s, year, week)
+		}
+	}
+}
+
+func TestParseDatetimeConstraints(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC) // 星期三
+	clock := func() time.Time { return now }
+	tests := []struct {
+		s    string
+		opts DatetimeOptions
+		want error
+	}{
+		{"2024-01-10T12:00:00Z", DatetimeOptions{NotFuture: true, Now: clock}, nil},
+		{"2024-01-10T12:00:01Z", DatetimeOptions{NotFuture: true, Now: clock}, ErrDatetimeFuture},
+		{"2024-01-10T20:00:00+08:00", DatetimeOptions{NotFuture: true, Now: clock}, nil},
+		{"2024-01-10T20:00:01+08:00", DatetimeOptions{NotFuture: true, Now: clock}, ErrDatetimeFuture},
+		{"2030-01-01T00:00:00Z", DatetimeOptions{Now: clock}, nil},
+
+		{"2024-01-03T12:00:00Z", DatetimeOptions{WithinDays: 7, Now: clock}, nil},
+		{"2024-01-03T11:59:59Z", DatetimeOptions{WithinDays: 7, Now: clock}, ErrDatetimeTooOld},
+		{"2024-01-11T00:00:00Z", DatetimeOptions{WithinDays: 7, Now: clock}, ErrDatetimeFuture},
+		{"2024-01-09T00:00:00Z", DatetimeOptions{WithinDays: 1, Now: clock}, ErrDatetimeTooOld},
+
+		{"2024-01-01T00:00:00Z", DatetimeOptions{After: now}, ErrDatetimeBefore},
+		{"2024-01-10T12:00:00Z", DatetimeOptions{After: now}, nil},
+		{"2024-02-01T00:00:00Z", DatetimeOptions{Before: now}, ErrDatetimeAfter},
+		{"2024-01-10T12:00:00Z", DatetimeOptions{Before: now}, nil},
+		{"bad", DatetimeOptions{NotFuture: true, Now: clock}, ErrBadDatetime},
+	}
+	for _, tt := range tests {
+		if err := CheckDatetime(tt.s, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckDatetime(%q, %+v) = %v, want %v", tt.s, tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestParseDatetimeWeekdayOnly(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	newYork := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		s      string
+		layout string
+		loc    *time.Location
+		want   error
+	}{
+		{"2024-01-05T10:00:00Z", DatetimeRFC3339, nil, nil},
+		{"2024-01-06T10:00:00Z", DatetimeRFC3339, nil, ErrDatetimeWeekend},
+		{"2024-01-07T10:00:00Z", DatetimeRFC3339, nil, ErrDatetimeWeekend},
+		// 按照值自身的时区判断：纽约的星期五晚上在 UTC 已是星期六
+		{"2024-01-05T22:00:00-05:00", DatetimeRFC3339, nil, nil},
+		// 上海的星期一早上在 UTC 仍是星期日
+		{"2024-01-08T06:00:00+08:00", DatetimeRFC3339, nil, nil},
+		{"2024-01-07T23:00:00-05:00", DatetimeRFC3339, nil, ErrDatetimeWeekend},
+		// 不含时区的格式与时间戳按照 Location 判断
+		{"2024-01-06 06:00:00", DatetimeDateTime, nil, ErrDatetimeWeekend},
+		{"2024-01-06", DatetimeDate, shanghai, ErrDatetimeWeekend},
+		{"1704668400", DatetimeUnix, nil, ErrDatetimeWeekend}, // 2024-01-07T23:00:00Z
+		{"1704668400", DatetimeUnix, shanghai, nil},           // 2024-01-08T07:00:00+08:00
+		{"1704506400", DatetimeUnix, nil, ErrDatetimeWeekend}, // 2024-01-06T02:00:00Z
+		{"1704506400", DatetimeUnix, newYork, nil},            // 2024-01-05T21:00:00-05:00
+	}
+	for _, tt := range tests {
+		opts := DatetimeOptions{Layouts: []string{tt.layout}, Location: tt.loc, WeekdayOnly: true}
+		if err := CheckDatetime(tt.s, opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckDatetime(%q, %s, %v) = %v, want %v", tt.s, tt.layout, tt.loc, err, tt.want)
+		}
+	}
+}
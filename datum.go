@@ -0,0 +1,176 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+var ErrBadDatum = errors.New("coordinate: unknown datum")
+
+// Datum 表示坐标所使用的大地坐标系
+type Datum string
+
+const (
+	// DatumWGS84 是 GPS 与国际通行地图使用的坐标系
+	DatumWGS84 Datum = "WGS-84"
+	// DatumGCJ02 是国测局坐标系（俗称火星坐标系），高德、腾讯地图等使用
+	DatumGCJ02 Datum = "GCJ-02"
+	// DatumBD09 是百度地图在 GCJ-02 基础上再次加偏的坐标系
+	DatumBD09 Datum = "BD-09"
+)
+
+// ParseDatum 解析坐标系名称，不区分大小写，并忽略 "-" 与 "_"，
+// 如 "wgs84"、"GCJ-02"、"bd_09"。
+func ParseDatum(s string) (Datum, error) {
+	switch strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(s)) {
+	case "wgs84":
+		return DatumWGS84, nil
+	case "gcj02":
+		return DatumGCJ02, nil
+	case "bd09":
+		return DatumBD09, nil
+	}
+	return "", ErrBadDatum
+}
+
+// DatumCoordinate 是带有坐标系的坐标
+type DatumCoordinate struct {
+	Coordinate
+	Datum Datum
+}
+
+// To 将坐标转换到给出的坐标系，坐标系无法识别时返回 ErrBadDatum
+func (c DatumCoordinate) To(datum Datum) (DatumCoordinate, error) {
+	p, err := ConvertDatum(c.Coordinate, c.Datum, datum)
+	if err != nil {
+		return DatumCoordinate{}, err
+	}
+	datum, _ = ParseDatum(string(datum))
+	return DatumCoordinate{Coordinate: p, Datum: datum}, nil
+}
+
+// ConvertDatum 在 WGS-84、GCJ-02 与 BD-09 之间转换坐标，坐标系名称按照 ParseDatum 解析，
+// 无法识别时返回 ErrBadDatum。
+//
+// 与常见的实现一致，GCJ-02 的偏移只作用于中国境内的粗略矩形范围，
+// GCJ-02 到 WGS-84 的转换通过迭代求解，误差小于 1 厘米。
+func ConvertDatum(c Coordinate, from, to Datum) (Coordinate, error) {
+	from, err := ParseDatum(string(from))
+	if err != nil {
+		return Coordinate{}, err
+	}
+	if to, err = ParseDatum(string(to)); err != nil {
+		return Coordinate{}, err
+	}
+	if from == to {
+		return c, nil
+	}
+	// 统一先转换到 GCJ-02
+	switch from {
+	case DatumWGS84:
+		c = wgs84ToGCJ02(c)
+	case DatumBD09:
+		c = bd09ToGCJ02(c)
+	}
+	switch to {
+	case DatumWGS84:
+		c = gcj02ToWGS84(c)
+	case DatumBD09:
+		c = gcj02ToBD09(c)
+	}
+	return c, nil
+}
+
+// outOfChina 判断坐标是否位于 GCJ-02 加偏范围之外
+func outOfChina(c Coordinate) bool {
+	return c.Lng < 72.004 || c.Lng > 137.8347 || c.Lat < 0.8293 || c.Lat > 55.8271
+}
+
+func wgs84ToGCJ02(c Coordinate) Coordinate {
+	if outOfChina(c) {
+		return c
+	}
+	// 克拉索夫斯基椭球的长半轴与第一偏心率的平方
+	const a, ee = 6378245.0, 0.00669342162296594323
+	x, y := c.Lng-105, c.Lat-35
+	dLat := -100 + 2*x + 3*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x)) +
+		(20*math.Sin(6*x*math.Pi)+20*math.Sin(2*x*math.Pi))*2/3 +
+		(20*math.Sin(y*math.Pi)+40*math.Sin(y/3*math.Pi))*2/3 +
+		(160*math.Sin(y/12*math.Pi)+320*math.Sin(y*math.Pi/30))*2/3
+	dLng := 300 + x + 2*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x)) +
+		(20*math.Sin(6*x*math.Pi)+20*math.Sin(2*x*math.Pi))*2/3 +
+		(20*math.Sin(x*math.Pi)+40*math.Sin(x/3*math.Pi))*2/3 +
+		(150*math.Sin(x/12*math.Pi)+300*math.Sin(x/30*math.Pi))*2/3
+	radLat := c.Lat / 180 * math.Pi
+	magic := 1 - ee*math.Sin(radLat)*math.Sin(radLat)
+	sqrtMagic := math.Sqrt(magic)
+	dLat = dLat * 180 / ((a * (1 - ee)) / (magic * sqrtMagic) * math.Pi)
+	dLng = dLng * 180 / (a / sqrtMagic * math.Cos(radLat) * math.Pi)
+	return Coordinate{Lat: c.Lat + dLat, Lng: c.Lng + dLng}
+}
+
+func gcj02ToWGS84(c Coordinate) Coordinate {
+	if outOfChina(c) {
+		return c
+	}
+	w := c
+	for i := 0; i < 30; i++ {
+		g := wgs84ToGCJ02(w)
+		dLat, dLng := g.Lat-c.Lat, g.Lng-c.Lng
+		w.Lat -= dLat
+		w.Lng -= dLng
+		if math.Abs(dLat) < 1e-9 && math.Abs(dLng) < 1e-9 {
+			break
+		}
+	}
+	return w
+}
+
+const bd09XPi = math.Pi * 3000 / 180
+
+func gcj02ToBD09(c Coordinate) Coordinate {
+	x, y := c.Lng, c.Lat
+	z := math.Sqrt(x*x+y*y) + 0.00002*math.Sin(y*bd09XPi)
+	theta := math.Atan2(y, x) + 0.000003*math.Cos(x*bd09XPi)
+	return Coordinate{Lat: z*math.Sin(theta) + 0.006, Lng: z*math.Cos(theta) + 0.0065}
+}
+
+func bd09ToGCJ02(c Coordinate) Coordinate {
+	x, y := c.Lng-0.0065, c.Lat-0.006
+	z := math.Sqrt(x*x+y*y) - 0.00002*math.Sin(y*bd09XPi)
+	theta := math.Atan2(y, x) - 0.000003*math.Cos(x*bd09XPi)
+	return Coordinate{Lat: z * math.Sin(theta), Lng: z * math.Cos(theta)}
+}
+
+// DatumGeofence 是带有坐标系的地理围栏，例如使用高德地图绘制的 GCJ-02 围栏
+type DatumGeofence struct {
+	Geofence
+	Datum Datum
+}
+
+// ContainsDatum 将坐标转换到围栏的坐标系后判断是否位于围栏内，
+// 坐标或围栏的坐标系无法识别时返回 false。
+//
+// 注意 Contains 方法来自内嵌的 Geofence，不做坐标系转换。
+func (g DatumGeofence) ContainsDatum(c DatumCoordinate) bool {
+	p, err := c.To(g.Datum)
+	return err == nil && g.Contains(p.Coordinate)
+}
+
+// InGeofenceDatum 判断 datum 坐标系下的坐标是否位于以 name 注册的地理围栏内。
+// 以 DatumGeofence 注册的围栏使用其自身的坐标系，其它围栏视为 WGS-84，
+// 围栏不存在或坐标系无法识别时返回 false。
+func InGeofenceDatum(c Coordinate, datum Datum, name string) bool {
+	geofencesMu.RLock()
+	g := geofences[name]
+	geofencesMu.RUnlock()
+	if g == nil {
+		return false
+	}
+	fence, ok := g.(DatumGeofence)
+	if !ok {
+		fence = DatumGeofence{Geofence: g, Datum: DatumWGS84}
+	}
+	return fence.ContainsDatum(DatumCoordinate{Coordinate: c, Datum: datum})
+}
@@ -0,0 +1,170 @@
+package is
+
+import (
+	"errors"
+	"testing"
+)
+
+var datumTestPoints = []Coordinate{
+	{39.9042, 116.4074}, // 北京
+	{31.2304, 121.4737}, // 上海
+	{22.5431, 114.0579}, // 深圳
+	{43.8256, 87.6168},  // 乌鲁木齐
+	{18.2528, 109.5119}, // 三亚
+	{53.4856, 122.3622}, // 漠河
+	{29.6520, 91.1721},  // 拉萨
+	{45.8038, 126.5350}, // 哈尔滨
+	{25.0330, 121.5654}, // 台北，同样位于加偏范围内
+	{35.0000, 105.0000}, // 加偏公式的原点
+	{0.8300, 72.0100},   // 加偏范围的西南角
+}
+
+func TestParseDatum(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Datum
+	}{
+		{"WGS-84", DatumWGS84},
+		{"wgs84", DatumWGS84},
+		{"WGS_84", DatumWGS84},
+		{"gcj02", DatumGCJ02},
+		{"GCJ-02", DatumGCJ02},
+		{"bd_09", DatumBD09},
+		{"BD09", DatumBD09},
+	}
+	for _, tt := range tests {
+		if got, err := ParseDatum(tt.s); err != nil || got != tt.want {
+			t.Errorf("ParseDatum(%q) = %q, %v, want %q", tt.s, got, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "cgcs2000", "wgs", "gcj", "bd09ll", "wgs 84"} {
+		if _, err := ParseDatum(s); !errors.Is(err, ErrBadDatum) {
+			t.Errorf("ParseDatum(%q) = %v, want ErrBadDatum", s, err)
+		}
+	}
+}
+
+func TestConvertDatumRoundTrip(t *testing.T) {
+	for _, c := range datumTestPoints {
+		gcj, err := ConvertDatum(c, DatumWGS84, DatumGCJ02)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// GCJ-02 的偏移约为数百米
+		if d := Distance(c, gcj); d < 10 || d > 1000 {
+			t.Errorf("WGS-84 → GCJ-02 %v moved %.1f m", c, d)
+		}
+		wgs, _ := ConvertDatum(gcj, DatumGCJ02, DatumWGS84)
+		if d := Distance(c, wgs); d >= 0.01 {
+			t.Errorf("WGS-84 → GCJ-02 → WGS-84 %v off by %.4f m", c, d)
+		}
+
+		bd, _ := ConvertDatum(gcj, DatumGCJ02, DatumBD09)
+		if d := Distance(gcj, bd); d < 100 || d > 1500 {
+			t.Errorf("GCJ-02 → BD-09 %v moved %.1f m", gcj, d)
+		}
+		back, _ := ConvertDatum(bd, DatumBD09, DatumGCJ02)
+		if d := Distance(gcj, back); d >= 1 {
+			t.Errorf("GCJ-02 → BD-09 → GCJ-02 %v off by %.4f m", gcj, d)
+		}
+		// BD-09 到 WGS-84 经由 GCJ-02
+		wgs, _ = ConvertDatum(bd, DatumBD09, DatumWGS84)
+		if d := Distance(c, wgs); d >= 1 {
+			t.Errorf("WGS-84 → BD-09 → WGS-84 %v off by %.4f m", c, d)
+		}
+	}
+}
+
+func TestConvertDatumOutOfChina(t *testing.T) {
+	outside := []Coordinate{
+		{51.5074, -0.1278},   // 伦敦
+		{40.7128, -74.0060},  // 纽约
+		{-33.8688, 151.2093}, // 悉尼
+		{0.5, 100},
+		{56, 120},
+		{40, 71.9},
+		{40, 138},
+	}
+	for _, c := range outside {
+		if gcj, _ := ConvertDatum(c, DatumWGS84, DatumGCJ02); gcj != c {
+			t.Errorf("WGS-84 → GCJ-02 %v = %v, want unchanged", c, gcj)
+		}
+		if wgs, _ := ConvertDatum(c, DatumGCJ02, DatumWGS84); wgs != c {
+			t.Errorf("GCJ-02 → WGS-84 %v = %v, want unchanged", c, wgs)
+		}
+	}
+	// 加偏范围是粗略的矩形，新加坡等周边地区也会被加偏
+	singapore := Coordinate{1.3521, 103.8198}
+	if gcj, _ := ConvertDatum(singapore, DatumWGS84, DatumGCJ02); gcj == singapore {
+		t.Errorf("WGS-84 → GCJ-02 %v unchanged inside the rough bounds", singapore)
+	}
+}
+
+func TestConvertDatumUnknown(t *testing.T) {
+	c := Coordinate{39.9042, 116.4074}
+	for _, pair := range [][2]Datum{{"cgcs2000", DatumWGS84}, {DatumWGS84, ""}, {"", ""}} {
+		if _, err := ConvertDatum(c, pair[0], pair[1]); !errors.Is(err, ErrBadDatum) {
+			t.Errorf("ConvertDatum(%q, %q) = %v, want ErrBadDatum", pair[0], pair[1], err)
+		}
+	}
+
+	// 非规范写法的名称按照 ParseDatum 识别
+	want, _ := ConvertDatum(c, DatumWGS84, DatumGCJ02)
+	if got, err := ConvertDatum(c, "wgs84", "gcj02"); err != nil || got != want {
+		t.Errorf("ConvertDatum(wgs84, gcj02) = %v, %v, want %v", got, err, want)
+	}
+	if got, err := ConvertDatum(c, "WGS_84", DatumWGS84); err != nil || got != c {
+		t.Errorf("ConvertDatum(WGS_84, WGS-84) = %v, %v, want unchanged", got, err)
+	}
+
+	dc, err := DatumCoordinate{Coordinate: c, Datum: "wgs84"}.To("gcj02")
+	if err != nil || dc.Datum != DatumGCJ02 || dc.Coordinate != want {
+		t.Errorf("To(gcj02) = %+v, %v", dc, err)
+	}
+	if _, err := (DatumCoordinate{Coordinate: c, Datum: "mars"}).To(DatumWGS84); !errors.Is(err, ErrBadDatum) {
+		t.Errorf("To() from unknown datum = %v, want ErrBadDatum", err)
+	}
+}
+
+func TestInGeofenceDatum(t *testing.T) {
+	wgs := Coordinate{39.9042, 116.4074}
+	gcj, _ := ConvertDatum(wgs, DatumWGS84, DatumGCJ02)
+	bd, _ := ConvertDatum(wgs, DatumWGS84, DatumBD09)
+	// 以 GCJ-02 坐标为中心、半径 50 米的围栏，WGS-84 坐标未经转换时位于围栏外
+	fence := Circle{Center: gcj, Radius: 50}
+	RegisterGeofence("test-datum-gcj", DatumGeofence{Geofence: fence, Datum: DatumGCJ02})
+	RegisterGeofence("test-datum-plain", Circle{Center: wgs, Radius: 50})
+	RegisterGeofence("test-datum-bad", DatumGeofence{Geofence: fence, Datum: "mars"})
+
+	tests := []struct {
+		c     Coordinate
+		datum Datum
+		name  string
+		want  bool
+	}{
+		{wgs, DatumWGS84, "test-datum-gcj", true},
+		{gcj, DatumGCJ02, "test-datum-gcj", true},
+		{bd, DatumBD09, "test-datum-gcj", true},
+		{gcj, DatumWGS84, "test-datum-gcj", false},
+		{gcj, "gcj02", "test-datum-gcj", true},
+		{gcj, "gcj", "test-datum-gcj", false},
+		{wgs, DatumWGS84, "test-datum-plain", true},
+		{gcj, DatumGCJ02, "test-datum-plain", true},
+		{gcj, DatumWGS84, "test-datum-plain", false},
+		{wgs, DatumWGS84, "test-datum-bad", false},
+		{wgs, DatumWGS84, "test-datum-missing", false},
+	}
+	for _, tt := range tests {
+		if got := InGeofenceDatum(tt.c, tt.datum, tt.name); got != tt.want {
+			t.Errorf("InGeofenceDatum(%v, %q, %q) = %v, want %v", tt.c, tt.datum, tt.name, got, tt.want)
+		}
+	}
+
+	// InGeofence 将坐标视为 WGS-84，并转换到 DatumGeofence 的坐标系
+	if !InGeofence(wgs, "test-datum-gcj") || InGeofence(gcj, "test-datum-gcj") {
+		t.Error("InGeofence ignored the datum of a DatumGeofence")
+	}
+	if !(DatumGeofence{Geofence: fence, Datum: DatumGCJ02}).ContainsDatum(DatumCoordinate{Coordinate: bd, Datum: DatumBD09}) {
+		t.Error("ContainsDatum did not convert BD-09 to GCJ-02")
+	}
+}
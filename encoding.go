@@ -0,0 +1,255 @@
+package is
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Padding 表示 base64、base32 编码的填充策略
+type Padding int
+
+const (
+	PaddingRequired  Padding = iota // 必须使用 "=" 填充
+	PaddingOptional                 // 可以省略填充
+	PaddingForbidden                // 不允许填充
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	z85Alphabet    = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+)
+
+var (
+	base58Decoding = alphabetDecoding(base58Alphabet)
+	z85Decoding    = alphabetDecoding(z85Alphabet)
+)
+
+// EncodingOptions 定义编码校验的策略，零值要求非空、使用填充（如适用）且不限制长度
+type EncodingOptions struct {
+	// Padding 填充策略，只对 base64 与 base32 生效
+	Padding Padding
+	// AllowEmpty 允许空字符串
+	AllowEmpty bool
+	// MinLength 解码后的最小字节数，为 0 时不限制
+	MinLength int
+	// MaxLength 解码后的最大字节数，为 0 时不限制
+	MaxLength int
+}
+
+func (opts EncodingOptions) check(s string, decode func(string) ([]byte, bool)) bool {
+	if s == "" {
+		return opts.AllowEmpty && opts.MinLength <= 0
+	}
+	b, ok := decode(s)
+	if !ok {
+		return false
+	}
+	return len(b) >= opts.MinLength && (opts.MaxLength <= 0 || len(b) <= opts.MaxLength)
+}
+
+func alphabetDecoding(alphabet string) (table [256]byte) {
+	for i := range table {
+		table[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		table[alphabet[i]] = byte(i)
+	}
+	return
+}
+
+// Base64With 按照给出的策略判断字符串是否为标准 base64 编码
+func Base64With(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		return decodePadded(s, opts.Padding, 4, base64.StdEncoding.Strict().DecodeString, base64.RawStdEncoding.Strict().DecodeString)
+	})
+}
+
+// Base64URLWith 按照给出的策略判断字符串是否为 URL 安全的 base64 编码
+func Base64URLWith(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		return decodePadded(s, opts.Padding, 4, base64.URLEncoding.Strict().DecodeString, base64.RawURLEncoding.Strict().DecodeString)
+	})
+}
+
+// Base32With 按照给出的策略判断字符串是否为标准字母表（RFC 4648）的 base32 编码
+func Base32With(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		return decodePadded(s, opts.Padding, 8, strictBase32(base32.StdEncoding), strictBase32(base32.StdEncoding.WithPadding(base32.NoPadding)))
+	})
+}
+
+// Base32HexWith 按照给出的策略判断字符串是否为扩展十六进制字母表（RFC 4648）的 base32 编码
+func Base32HexWith(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		return decodePadded(s, opts.Padding, 8, strictBase32(base32.HexEncoding), strictBase32(base32.HexEncoding.WithPadding(base32.NoPadding)))
+	})
+}
+
+// strictBase32 返回严格模式的 base32 解码函数。encoding/base32 没有 base64 那样的
+// Strict 方法，末尾未使用的位不为零时（如 "MZ======"）也能解码，这里通过重新编码来拒绝。
+func strictBase32(enc *base32.Encoding) func(string) ([]byte, error) {
+	return func(s string) ([]byte, error) {
+		b, err := enc.DecodeString(s)
+		if err == nil && enc.EncodeToString(b) != s {
+			err = base32.CorruptInputError(len(s) - 1)
+		}
+		return b, err
+	}
+}
+
+// decodePadded 按照填充策略选择带填充或不带填充的解码器，
+// 标准库的解码器会忽略换行符，这里将其视为无效字符。
+func decodePadded(s string, padding Padding, block int, padded, raw func(string) ([]byte, error)) ([]byte, bool) {
+	if strings.ContainsAny(s, "\r\n") {
+		return nil, false
+	}
+	decode := padded
+	switch padding {
+	case PaddingForbidden:
+		decode = raw
+	case PaddingOptional:
+		if len(s)%block != 0 {
+			decode = raw
+		}
+	}
+	b, err := decode(s)
+	return b, err == nil
+}
+
+// Base58With 按照给出的策略判断字符串是否为比特币字母表的 base58 编码
+func Base58With(s string, opts EncodingOptions) bool {
+	return opts.check(s, decodeBase58)
+}
+
+// Base58CheckWith 按照给出的策略判断字符串是否为带有校验和的 base58 编码，
+// 即解码后末尾 4 个字节等于其余部分两次 SHA-256 摘要的前 4 个字节。
+// 解码长度约束作用于去掉校验和后的数据。
+func Base58CheckWith(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		b, ok := decodeBase58(s)
+		if !ok || len(b) < 4 {
+			return nil, false
+		}
+		payload, checksum := b[:len(b)-4], b[len(b)-4:]
+		first := sha256.Sum256(payload)
+		second := sha256.Sum256(first[:])
+		if !bytes.Equal(second[:4], checksum) {
+			return nil, false
+		}
+		return payload, true
+	})
+}
+
+func decodeBase58(s string) ([]byte, bool) {
+	// 每个前导的 "1" 表示一个前导零字节
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	// 以大端字节序逐位累乘 58，log(58)/log(256) 约为 0.733
+	out := make([]byte, (len(s)-zeros)*733/1000+1)
+	for i := zeros; i < len(s); i++ {
+		carry := uint32(base58Decoding[s[i]])
+		if carry == 0xff {
+			return nil, false
+		}
+		for j := len(out) - 1; j >= 0; j-- {
+			carry += uint32(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+	}
+	n := 0
+	for n < len(out) && out[n] == 0 {
+		n++
+	}
+	return append(make([]byte, zeros), out[n:]...), true
+}
+
+// ASCII85With 按照给出的策略判断字符串是否为 Ascii85 编码，
+// 允许 Adobe 风格的 "<~" 与 "~>" 定界符以及表示四个零字节的 "z"。
+func ASCII85With(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		if strings.HasPrefix(s, "<~") && strings.HasSuffix(s, "~>") && len(s) >= 4 {
+			s = s[2 : len(s)-2]
+		}
+		// 标准库的解码器会忽略空白字符
+		if strings.ContainsAny(s, " \t\r\n\v\f") {
+			return nil, false
+		}
+		// 标准库的解码器不检查溢出，"uuuuu" 这类超过 2^32-1 的分组会被截断
+		if ascii85Overflow(s) {
+			return nil, false
+		}
+		dst := make([]byte, 4*len(s))
+		n, _, err := ascii85.Decode(dst, []byte(s), true)
+		if err != nil {
+			return nil, false
+		}
+		return dst[:n], true
+	})
+}
+
+// ascii85Overflow 判断是否有 5 个字符的分组的值超过 0xffffffff，
+// 末尾不足 5 个字符的分组按照解码规则以 "u" 补齐后计算。
+func ascii85Overflow(s string) bool {
+	var v uint64
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'z' && n == 0 {
+			continue
+		}
+		v = v*85 + uint64(s[i]-'!')
+		if n++; n == 5 {
+			if v > 0xffffffff {
+				return true
+			}
+			v, n = 0, 0
+		}
+	}
+	if n > 0 {
+		for ; n < 5; n++ {
+			v = v*85 + 84
+		}
+		return v > 0xffffffff
+	}
+	return false
+}
+
+// Z85With 按照给出的策略判断字符串是否为 ZeroMQ 的 Z85 编码，长度必须是 5 的倍数
+func Z85With(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		if len(s)%5 != 0 {
+			return nil, false
+		}
+		out := make([]byte, 0, len(s)/5*4)
+		for i := 0; i < len(s); i += 5 {
+			var v uint64
+			for j := i; j < i+5; j++ {
+				d := z85Decoding[s[j]]
+				if d == 0xff {
+					return nil, false
+				}
+				v = v*85 + uint64(d)
+			}
+			if v > 0xffffffff {
+				return nil, false
+			}
+			out = append(out, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+		}
+		return out, true
+	})
+}
+
+// HexWith 按照给出的策略判断字符串是否为偶数长度、不带 "0x" 前缀的十六进制编码，不区分大小写
+func HexWith(s string, opts EncodingOptions) bool {
+	return opts.check(s, func(s string) ([]byte, bool) {
+		b, err := hex.DecodeString(s)
+		return b, err == nil
+	})
+}
@@ -0,0 +1,84 @@
+package is
+
+import "testing"
+
+func TestASCII85With(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"87cURD]i,\"Ebo80", true},
+		{"<~87cURD]i,\"Ebo80~>", true},
+		{"z", true},
+		{"zz!!", true},
+		{"s8W-!", true},
+		{"s8W*", true},
+		{"rr", true},
+		{"s8W-", false},
+		{"uuuuu", false},
+		{"s8W-\"", false},
+		{"zuuuuu", false},
+		{"uuuu", false},
+		{"87cURD]i,\" Ebo80", false},
+		{"87cUR{", false},
+	}
+	for _, tt := range tests {
+		if got := ASCII85With(tt.s, EncodingOptions{}); got != tt.want {
+			t.Errorf("ASCII85With(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestZ85With(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"HelloWorld", true},
+		{"%nSc0", true},
+		{"%nSc1", false},
+		{"Hello", true},
+		{"Hell", false},
+		{"Hell~", false},
+	}
+	for _, tt := range tests {
+		if got := Z85With(tt.s, EncodingOptions{}); got != tt.want {
+			t.Errorf("Z85With(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestBase32With(t *testing.T) {
+	tests := []struct {
+		s       string
+		padding Padding
+		hex     bool
+		want    bool
+	}{
+		{"MZXW6===", PaddingRequired, false, true},
+		{"MZXW6", PaddingForbidden, false, true},
+		{"MZXW6", PaddingOptional, false, true},
+		{"MZXW6===", PaddingOptional, false, true},
+		{"MZXW6", PaddingRequired, false, false},
+		{"MZXW6===", PaddingForbidden, false, false},
+		{"MY======", PaddingRequired, false, true},
+		{"MZ======", PaddingRequired, false, false},
+		{"MZXW7===", PaddingRequired, false, false},
+		{"MZ", PaddingForbidden, false, false},
+		{"mzxw6===", PaddingRequired, false, false},
+		{"MZXW6===\n", PaddingRequired, false, false},
+		{"CPNMU===", PaddingRequired, true, true},
+		{"CPNMU", PaddingForbidden, true, true},
+		{"CPNMV===", PaddingRequired, true, false},
+		{"CP", PaddingForbidden, true, false},
+	}
+	for _, tt := range tests {
+		validate, name := Base32With, "Base32With"
+		if tt.hex {
+			validate, name = Base32HexWith, "Base32HexWith"
+		}
+		if got := validate(tt.s, EncodingOptions{Padding: tt.padding}); got != tt.want {
+			t.Errorf("%s(%q, Padding: %d) = %v, want %v", name, tt.s, tt.padding, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrBadCoordinate       = errors.New("coordinate: invalid syntax")
+	ErrCoordinateRange     = errors.New("coordinate: out of range")
+	ErrCoordinatePrecision = errors.New("coordinate: precision out of range")
+)
+
+// dmsRegex 匹配度分秒写法，如 39°54'26"N、-116° 23′ 29.5″，分与秒可以省略
+var dmsRegex = regexp.MustCompile(`^([-+])?(\d+(?:\.\d+)?)\s*[°º]\s*(?:(\d+(?:\.\d+)?)\s*['′’]\s*(?:(\d+(?:\.\d+)?)\s*(?:"|″|”|'')\s*)?)?([NSEWnsew])?$`)
+
+// toDegrees 将数值或十进制字符串转换为度数
+func toDegrees(val any) (float64, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	case reflect.String:
+		return parseDecimal(rv.String())
+	}
+	return 0, false
+}
+
+// Latitude 判断给出的数值或十进制字符串是否为有效的纬度（-90 至 90）
+func Latitude[T any](t T) bool {
+	v, ok := toDegrees(t)
+	return ok && v >= -90 && v <= 90
+}
+
+// Longitude 判断给出的数值或十进制字符串是否为有效的经度（-180 至 180）
+func Longitude[T any](t T) bool {
+	v, ok := toDegrees(t)
+	return ok && v >= -180 && v <= 180
+}
+
+// ParseDMS 解析度分秒写法的角度，如 39°54'26"N、116°23′29.5″E、-39°54.5'，
+// 返回十进制度数与大写的半球字母（没有时为 0），南纬与西经的结果为负数。
+// 分与秒必须小于 60，符号与半球字母不能同时出现。
+func ParseDMS(s string) (float64, byte, error) {
+	m := dmsRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, ErrBadCoordinate
+	}
+	if m[1] != "" && m[5] != "" {
+		return 0, 0, ErrBadCoordinate
+	}
+	deg, _ := strconv.ParseFloat(m[2], 64)
+	var minutes, seconds float64
+	if m[3] != "" {
+		minutes, _ = strconv.ParseFloat(m[3], 64)
+		// 度数或分带小数时不能再给出更小的单位
+		if minutes >= 60 || strings.Contains(m[2], ".") {
+			return 0, 0, ErrBadCoordinate
+		}
+	}
+	if m[4] != "" {
+		seconds, _ = strconv.ParseFloat(m[4], 64)
+		if seconds >= 60 || strings.Contains(m[3], ".") {
+			return 0, 0, ErrBadCoordinate
+		}
+	}
+	v := deg + minutes/60 + seconds/3600
+	hemisphere := byte(0)
+	if m[5] != "" {
+		hemisphere = m[5][0] &^ 0x20
+	}
+	if m[1] == "-" || hemisphere == 'S' || hemisphere == 'W' {
+		v = -v
+	}
+	if v < -180 || v > 180 {
+		return 0, 0, ErrCoordinateRange
+	}
+	return v, hemisphere, nil
+}
+
+// DMSLatitude 判断给出的字符串是否为度分秒写法的纬度，半球字母只能是 N 或 S
+func DMSLatitude(s string) bool {
+	v, h, err := ParseDMS(s)
+	return err == nil && h != 'E' && h != 'W' && v >= -90 && v <= 90
+}
+
+// DMSLongitude 判断给出的字符串是否为度分秒写法的经度，半球字母只能是 E 或 W
+func DMSLongitude(s string) bool {
+	_, h, err := ParseDMS(s)
+	return err == nil && h != 'N' && h != 'S'
+}
+
+// Coordinate 是以十进制度数表示的经纬度坐标
+type Coordinate struct {
+	Lat float64
+	Lng float64
+}
+
+// Valid 判断坐标是否在有效范围内
+func (c Coordinate) Valid() bool {
+	return Latitude(c.Lat) && Longitude(c.Lng)
+}
+
+// Precision 返回纬度与经度中较多的小数位数，按最短的十进制表示计算
+func (c Coordinate) Precision() int {
+	return max(decimalPlaces(c.Lat), decimalPlaces(c.Lng))
+}
+
+// String 返回 "lat,lng" 形式的坐标
+func (c Coordinate) String() string {
+	return strconv.FormatFloat(c.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(c.Lng, 'f', -1, 64)
+}
+
+func decimalPlaces(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// CoordinateOptions 定义坐标字符串的精度约束，零值不做限制
+type CoordinateOptions struct {
+	// MinPrecision 经纬度的最少小数位数
+	MinPrecision int
+	// MaxPrecision 经纬度的最多小数位数，为 0 时不限制
+	MaxPrecision int
+}
+
+// Check 检查坐标是否在有效范围内，并按照 Precision 检查精度
+func (c Coordinate) Check(opts CoordinateOptions) error {
+	if !c.Valid() {
+		return ErrCoordinateRange
+	}
+	for _, n := range []int{decimalPlaces(c.Lat), decimalPlaces(c.Lng)} {
+		if n < opts.MinPrecision || (opts.MaxPrecision > 0 && n > opts.MaxPrecision) {
+			return ErrCoordinatePrecision
+		}
+	}
+	return nil
+}
+
+// ParseLatLng 解析 "lat,lng" 形式的坐标，逗号前后可以有空白。
+//
+// 经纬度也可以使用度分秒写法，如 39°54'26"N,116°23'29"E，此时逗号可以替换为空白；
+// 若两部分的半球字母表明先经度后纬度（如 116°23'E 39°54'N），会自动交换。
+func ParseLatLng(s string) (Coordinate, error) {
+	c, _, err := parseLatLng(s)
+	return c, err
+}
+
+// parseLatLng 解析坐标，并返回十进制写法的纬度与经度文本（度分秒写法时为空）
+func parseLatLng(s string) (Coordinate, [2]string, error) {
+	var parts [2]string
+	if lat, lng, ok := strings.Cut(s, ","); ok {
+		parts = [2]string{strings.TrimSpace(lat), strings.TrimSpace(lng)}
+	} else if i := strings.IndexAny(s, "NSEWnsew"); i >= 0 && strings.ContainsAny(s, "°º") {
+		parts = [2]string{strings.TrimSpace(s[:i+1]), strings.TrimSpace(s[i+1:])}
+	} else if fields := strings.Fields(s); len(fields) == 2 {
+		parts = [2]string{fields[0], fields[1]}
+	} else {
+		return Coordinate{}, parts, ErrBadCoordinate
+	}
+	var values [2]float64
+	var hemispheres [2]byte
+	for i, part := range parts {
+		if strings.ContainsAny(part, "°º") {
+			v, h, err := ParseDMS(part)
+			if err != nil {
+				return Coordinate{}, parts, err
+			}
+			values[i], hemispheres[i], parts[i] = v, h, ""
+		} else if v, ok := parseDecimal(part); ok {
+			values[i] = v
+		} else {
+			return Coordinate{}, parts, ErrBadCoordinate
+		}
+	}
+	if (hemispheres[0] == 'E' || hemispheres[0] == 'W') && (hemispheres[1] == 'N' || hemispheres[1] == 'S') {
+		values[0], values[1] = values[1], values[0]
+		hemispheres[0], hemispheres[1] = hemispheres[1], hemispheres[0]
+		parts[0], parts[1] = parts[1], parts[0]
+	}
+	if hemispheres[0] == 'E' || hemispheres[0] == 'W' || hemispheres[1] == 'N' || hemispheres[1] == 'S' {
+		return Coordinate{}, parts, ErrBadCoordinate
+	}
+	c := Coordinate{Lat: values[0], Lng: values[1]}
+	if !c.Valid() {
+		return Coordinate{}, parts, ErrCoordinateRange
+	}
+	return c, parts, nil
+}
+
+// CheckLatLng 解析 "lat,lng" 形式的坐标并检查精度，精度按照文本中的小数位数计算
+// （"39.900" 为 3 位），度分秒写法的部分不检查精度。
+func CheckLatLng(s string, opts CoordinateOptions) error {
+	c, parts, err := parseLatLng(s)
+	if err != nil {
+		return err
+	}
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		n := 0
+		if strings.ContainsAny(part, "eE") {
+			n = decimalPlaces([2]float64{c.Lat, c.Lng}[i])
+		} else if _, frac, ok := strings.Cut(part, "."); ok {
+			n = len(frac)
+		}
+		if n < opts.MinPrecision || (opts.MaxPrecision > 0 && n > opts.MaxPrecision) {
+			return ErrCoordinatePrecision
+		}
+	}
+	return nil
+}
+
+// LatLng 判断给出的字符串是否为 "lat,lng" 形式的有效坐标，参见 ParseLatLng
+func LatLng(s string) bool {
+	_, err := ParseLatLng(s)
+	return err == nil
+}
@@ -0,0 +1,209 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+)
+
+func TestLatitudeLongitude(t *testing.T) {
+	tests := []struct {
+		val      any
+		lat, lng bool
+	}{
+		{0, true, true},
+		{90, true, true},
+		{-90, true, true},
+		{91, false, true},
+		{uint8(180), false, true},
+		{181, false, false},
+		{-180.0, false, true},
+		{float32(45.5), true, true},
+		{math.NaN(), false, false},
+		{math.Inf(1), false, false},
+		{"39.9042", true, true},
+		{"-116.4074", false, true},
+		{"+90", true, true},
+		{"90.0000001", false, true},
+		{"1e1", true, true},
+		{".5", true, true},
+		{"", false, false},
+		{"abc", false, false},
+		{"45,5", false, false},
+		{" 45", false, false},
+		{"0x10", false, false},
+		{true, false, false},
+		{nil, false, false},
+	}
+	for _, tt := range tests {
+		if got := Latitude(tt.val); got != tt.lat {
+			t.Errorf("Latitude(%#v) = %v, want %v", tt.val, got, tt.lat)
+		}
+		if got := Longitude(tt.val); got != tt.lng {
+			t.Errorf("Longitude(%#v) = %v, want %v", tt.val, got, tt.lng)
+		}
+	}
+}
+
+// TestLatitudeLongitudeLegacyForms 确认原先基于正则表达式的实现接受的字符串仍然有效
+func TestLatitudeLongitudeLegacyForms(t *testing.T) {
+	legacyLat := regexp.MustCompile(`^[-+]?([1-8]?\d(\.\d+)?|90(\.0+)?)$`)
+	legacyLng := regexp.MustCompile(`^[-+]?(180(\.0+)?|((1[0-7]\d)|([1-9]?\d))(\.\d+)?)$`)
+	inputs := []string{
+		"0", "-0", "+0", "9", "08", "08.5", "89.999999", "90", "90.0", "-90.000", "+45.5",
+		"179.9999", "-180", "180.00", "+180", "99", "100.5", "7.0", "-0.0000001",
+	}
+	for _, s := range inputs {
+		if legacyLat.MatchString(s) && !Latitude(s) {
+			t.Errorf("Latitude(%q) = false, previously accepted", s)
+		}
+		if legacyLng.MatchString(s) && !Longitude(s) {
+			t.Errorf("Longitude(%q) = false, previously accepted", s)
+		}
+	}
+}
+
+func TestParseDMS(t *testing.T) {
+	tests := []struct {
+		s          string
+		want       float64
+		hemisphere byte
+		err        error
+	}{
+		{`39°54'26"N`, 39 + 54.0/60 + 26.0/3600, 'N', nil},
+		{`116°23′29.5″E`, 116 + 23.0/60 + 29.5/3600, 'E', nil},
+		{`33°52'S`, -(33 + 52.0/60), 'S', nil},
+		{`-39°54.5'`, -(39 + 54.5/60), 0, nil},
+		{`74° 0' 21" w`, -(74 + 21.0/3600), 'W', nil},
+		{`45.5°`, 45.5, 0, nil},
+		{`12º30'15''N`, 12 + 30.0/60 + 15.0/3600, 'N', nil},
+		{`180°W`, -180, 'W', nil},
+
+		{`39°60'N`, 0, 0, ErrBadCoordinate},
+		{`39°54'60"N`, 0, 0, ErrBadCoordinate},
+		{`39.5°30'N`, 0, 0, ErrBadCoordinate},
+		{`39°54.5'30"N`, 0, 0, ErrBadCoordinate},
+		{`-39°54'S`, 0, 0, ErrBadCoordinate},
+		{`39°54'X`, 0, 0, ErrBadCoordinate},
+		{`39 54 26 N`, 0, 0, ErrBadCoordinate},
+		{``, 0, 0, ErrBadCoordinate},
+		{`181°E`, 0, 0, ErrCoordinateRange},
+	}
+	for _, tt := range tests {
+		v, h, err := ParseDMS(tt.s)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseDMS(%q) = %v, want %v", tt.s, err, tt.err)
+			continue
+		}
+		if err == nil && (math.Abs(v-tt.want) > 1e-9 || h != tt.hemisphere) {
+			t.Errorf("ParseDMS(%q) = %v, %c, want %v, %c", tt.s, v, h, tt.want, tt.hemisphere)
+		}
+	}
+
+	if !DMSLatitude(`39°54'26"N`) || DMSLatitude(`116°23'29"E`) || DMSLatitude(`91°N`) {
+		t.Error("DMSLatitude did not check hemisphere and range")
+	}
+	if !DMSLongitude(`116°23'29"E`) || DMSLongitude(`39°54'26"N`) || !DMSLongitude(`-170°`) {
+		t.Error("DMSLongitude did not check hemisphere")
+	}
+}
+
+func TestParseLatLng(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Coordinate
+		err  error
+	}{
+		{"39.9042,116.4074", Coordinate{39.9042, 116.4074}, nil},
+		{" -33.8688 , 151.2093 ", Coordinate{-33.8688, 151.2093}, nil},
+		{"0 0", Coordinate{0, 0}, nil},
+		{`39°54'N,116°23'E`, Coordinate{39.9, 116 + 23.0/60}, nil},
+		{`39°54'N 116°23'E`, Coordinate{39.9, 116 + 23.0/60}, nil},
+		{`116°23'E 39°54'N`, Coordinate{39.9, 116 + 23.0/60}, nil},
+		{`39.9,116°23'E`, Coordinate{39.9, 116 + 23.0/60}, nil},
+
+		{"91,0", Coordinate{}, ErrCoordinateRange},
+		{"0,181", Coordinate{}, ErrCoordinateRange},
+		{`116°E,116°E`, Coordinate{}, ErrBadCoordinate},
+		{`39°N,39°N`, Coordinate{}, ErrBadCoordinate},
+		{"39.9", Coordinate{}, ErrBadCoordinate},
+		{"a,b", Coordinate{}, ErrBadCoordinate},
+		{"1 2 3", Coordinate{}, ErrBadCoordinate},
+		{"", Coordinate{}, ErrBadCoordinate},
+	}
+	for _, tt := range tests {
+		c, err := ParseLatLng(tt.s)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseLatLng(%q) = %v, want %v", tt.s, err, tt.err)
+			continue
+		}
+		if err == nil && (math.Abs(c.Lat-tt.want.Lat) > 1e-9 || math.Abs(c.Lng-tt.want.Lng) > 1e-9) {
+			t.Errorf("ParseLatLng(%q) = %v, want %v", tt.s, c, tt.want)
+		}
+		if got := LatLng(tt.s); got != (tt.err == nil) {
+			t.Errorf("LatLng(%q) = %v", tt.s, got)
+		}
+	}
+}
+
+func TestCoordinatePrecision(t *testing.T) {
+	tests := []struct {
+		c    Coordinate
+		want int
+		str  string
+	}{
+		{Coordinate{39, 116}, 0, "39,116"},
+		{Coordinate{39.9, 116.4074}, 4, "39.9,116.4074"},
+		{Coordinate{-33.868820, 151.209296}, 6, "-33.86882,151.209296"},
+		{Coordinate{1e-7, 0}, 7, "0.0000001,0"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Precision(); got != tt.want {
+			t.Errorf("%v.Precision() = %d, want %d", tt.c, got, tt.want)
+		}
+		if got := tt.c.String(); got != tt.str {
+			t.Errorf("String() = %s, want %s", got, tt.str)
+		}
+	}
+
+	opts := CoordinateOptions{MinPrecision: 2, MaxPrecision: 5}
+	checks := []struct {
+		c   Coordinate
+		err error
+	}{
+		{Coordinate{39.92, 116.41}, nil},
+		// 按最短的十进制表示计算，39.90 只有 1 位小数
+		{Coordinate{39.90, 116.41}, ErrCoordinatePrecision},
+		{Coordinate{39.9, 116.41}, ErrCoordinatePrecision},
+		{Coordinate{39.904211, 116.41}, ErrCoordinatePrecision},
+		{Coordinate{91.12, 116.41}, ErrCoordinateRange},
+	}
+	for _, tt := range checks {
+		if err := tt.c.Check(opts); !errors.Is(err, tt.err) {
+			t.Errorf("%v.Check() = %v, want %v", tt.c, err, tt.err)
+		}
+	}
+}
+
+func TestCheckLatLng(t *testing.T) {
+	opts := CoordinateOptions{MinPrecision: 3, MaxPrecision: 6}
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"39.900,116.400", nil},
+		{"39.9,116.400", ErrCoordinatePrecision},
+		{"39.9000000,116.400", ErrCoordinatePrecision},
+		{"3.99042e1,116.400", nil},
+		{"3.9900e1,116.400", ErrCoordinatePrecision},
+		{`39°54'N,116.400`, nil},
+		{"99.000,116.400", ErrCoordinateRange},
+		{"x", ErrBadCoordinate},
+	}
+	for _, tt := range tests {
+		if err := CheckLatLng(tt.s, opts); !errors.Is(err, tt.err) {
+			t.Errorf("CheckLatLng(%q) = %v, want %v", tt.s, err, tt.err)
+		}
+	}
+}
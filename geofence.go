@@ -0,0 +1,291 @@
+package is
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+)
+
+// earthRadius 是地球的平均半径（米）
+const earthRadius = 6371008.8
+
+var (
+	ErrBadGeoJSON = errors.New("geojson: invalid object")
+
+	geofencesMu sync.RWMutex
+	geofences   = map[string]Geofence{}
+)
+
+// Geofence 表示一个地理围栏
+type Geofence interface {
+	// Contains 判断坐标是否位于围栏内
+	Contains(c Coordinate) bool
+}
+
+// BoundingBox 是以经纬度范围表示的矩形区域，MinLng 大于 MaxLng 时表示跨越 180 度经线的区域
+type BoundingBox struct {
+	MinLat, MinLng float64
+	MaxLat, MaxLng float64
+}
+
+// Contains 判断坐标是否位于矩形区域内，包括边界
+func (b BoundingBox) Contains(c Coordinate) bool {
+	if c.Lat < b.MinLat || c.Lat > b.MaxLat {
+		return false
+	}
+	if b.MinLng <= b.MaxLng {
+		return c.Lng >= b.MinLng && c.Lng <= b.MaxLng
+	}
+	return c.Lng >= b.MinLng || c.Lng <= b.MaxLng
+}
+
+// Circle 是以中心点与半径（米）表示的圆形区域
+type Circle struct {
+	Center Coordinate
+	Radius float64
+}
+
+// Contains 判断坐标与中心点的大圆距离是否不超过半径
+func (c Circle) Contains(p Coordinate) bool {
+	return Distance(c.Center, p) <= c.Radius
+}
+
+// Polygon 是由若干个闭合或不闭合的环组成的多边形，第一个环为外边界，其余的环为洞。
+// 判断时将经纬度视为平面坐标，不处理跨越 180 度经线的多边形。
+type Polygon [][]Coordinate
+
+// Contains 判断坐标是否位于多边形内，外边界上的点视为在内，洞内的点视为在外
+func (p Polygon) Contains(c Coordinate) bool {
+	if len(p) == 0 {
+		return false
+	}
+	if inside, onEdge := ringContains(p[0], c); !inside && !onEdge {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if inside, onEdge := ringContains(hole, c); inside && !onEdge {
+			return false
+		}
+	}
+	return true
+}
+
+// MultiPolygon 是多个多边形的并集
+type MultiPolygon []Polygon
+
+// Contains 判断坐标是否位于任意一个多边形内
+func (m MultiPolygon) Contains(c Coordinate) bool {
+	for _, p := range m {
+		if p.Contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains 使用射线法判断点是否位于环内，并单独报告点是否位于环的边上
+func ringContains(ring []Coordinate, c Coordinate) (inside, onEdge bool) {
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if onSegment(a, b, c) {
+			return false, true
+		}
+		if (a.Lat > c.Lat) != (b.Lat > c.Lat) &&
+			c.Lng < (b.Lng-a.Lng)*(c.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside, false
+}
+
+func onSegment(a, b, c Coordinate) bool {
+	cross := (b.Lng-a.Lng)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lng-a.Lng)
+	if math.Abs(cross) > 1e-12 {
+		return false
+	}
+	return c.Lng >= math.Min(a.Lng, b.Lng) && c.Lng <= math.Max(a.Lng, b.Lng) &&
+		c.Lat >= math.Min(a.Lat, b.Lat) && c.Lat <= math.Max(a.Lat, b.Lat)
+}
+
+// Distance 使用半正矢（haversine）公式返回两个坐标之间的大圆距离（米）
+func Distance(a, b Coordinate) float64 {
+	rad := math.Pi / 180
+	dLat := (b.Lat - a.Lat) * rad
+	dLng := (b.Lng - a.Lng) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(a.Lat*rad)*math.Cos(b.Lat*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// WithinRadius 判断坐标与中心点的距离是否不超过给出的米数
+func WithinRadius(c, center Coordinate, meters float64) bool {
+	return Circle{Center: center, Radius: meters}.Contains(c)
+}
+
+// ParseGeofence 从 GeoJSON 中解析多边形围栏，支持 Polygon 与 MultiPolygon 几何对象、
+// 几何对象为多边形的 Feature，以及由这样的 Feature 组成的 FeatureCollection（取并集）。
+// GeoJSON 中的位置以 [经度, 纬度] 表示。
+func ParseGeofence(data []byte) (MultiPolygon, error) {
+	var obj struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometry    json.RawMessage   `json:"geometry"`
+		Features    []json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, ErrBadGeoJSON
+	}
+	switch obj.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &rings); err != nil {
+			return nil, ErrBadGeoJSON
+		}
+		p, err := toPolygon(rings)
+		if err != nil {
+			return nil, err
+		}
+		return MultiPolygon{p}, nil
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &polygons); err != nil {
+			return nil, ErrBadGeoJSON
+		}
+		m := make(MultiPolygon, 0, len(polygons))
+		for _, rings := range polygons {
+			p, err := toPolygon(rings)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, p)
+		}
+		return m, nil
+	case "Feature":
+		return ParseGeofence(obj.Geometry)
+	case "FeatureCollection":
+		var m MultiPolygon
+		for _, f := range obj.Features {
+			fm, err := ParseGeofence(f)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, fm...)
+		}
+		return m, nil
+	}
+	return nil, ErrBadGeoJSON
+}
+
+func toPolygon(rings [][][]float64) (Polygon, error) {
+	if len(rings) == 0 {
+		return nil, ErrBadGeoJSON
+	}
+	p := make(Polygon, len(rings))
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return nil, ErrBadGeoJSON
+		}
+		p[i] = make([]Coordinate, len(ring))
+		for j, pos := range ring {
+			if len(pos) < 2 {
+				return nil, ErrBadGeoJSON
+			}
+			c := Coordinate{Lat: pos[1], Lng: pos[0]}
+			if !c.Valid() {
+				return nil, ErrCoordinateRange
+			}
+			p[i][j] = c
+		}
+	}
+	return p, nil
+}
+
+// RegisterGeofence 以给定的名称注册地理围栏，供 InGeofence 使用，
+// 重复注册同名围栏会覆盖之前的值。
+func RegisterGeofence(name string, g Geofence) {
+	geofencesMu.Lock()
+	defer geofencesMu.Unlock()
+	geofences[name] = g
+}
+
+// InGeofence 判断 WGS-84 坐标是否位于以 name 注册的地理围栏内，围栏不存在时返回 false。
+// 以 DatumGeofence 注册的围栏会先将坐标转换到其坐标系，参见 InGeofenceDatum。
+func InGeofence(c Coordinate, name string) bool {
+	return InGeofenceDatum(c, DatumWGS84, name)
+}
+
+// MainlandChina 判断坐标是否位于中国大陆（含海南岛，不含港澳台），
+// 使用的是误差在数十公里以内的粗略边界，只适合用于粗筛，
+// 需要精确判断时请使用 ParseGeofence 加载行政区划数据。
+func MainlandChina(c Coordinate) bool {
+	return mainlandChina.Contains(c)
+}
+
+var mainlandChina = func() MultiPolygon {
+	toRing := func(points [][2]float64) []Coordinate {
+		ring := make([]Coordinate, len(points))
+		for i, p := range points {
+			ring[i] = Coordinate{Lat: p[1], Lng: p[0]}
+		}
+		return ring
+	}
+	// 以下各点均为 [经度, 纬度]
+	mainland := toRing([][2]float64{
+		// 乌苏里江、图们江、鸭绿江
+		{134.75, 48.3}, {133.9, 46.7}, {133.1, 45.1}, {132.0, 45.3}, {131.5, 44.95},
+		{131.25, 44.75}, {131.3, 44.4}, {131.3, 43.5}, {130.9, 42.9}, {130.65, 42.42}, {130.25, 42.6}, {129.85, 42.97},
+		{129.3, 42.45}, {128.1, 42.0}, {127.0, 41.6}, {126.0, 40.9}, {124.3, 39.9},
+		// 渤海、黄海、东海沿岸
+		{121.15, 38.72}, {121.6, 39.5}, {122.2, 40.65}, {121.1, 40.8}, {119.8, 39.95},
+		{118.9, 39.1}, {117.8, 38.9}, {117.7, 38.4}, {119.2, 37.7}, {119.9, 37.2},
+		{120.8, 37.8}, {122.2, 37.5}, {122.7, 37.4}, {122.4, 36.9}, {120.7, 36.1},
+		{119.6, 35.4}, {119.5, 34.7}, {120.5, 33.3}, {121.0, 32.5}, {121.9, 31.75},
+		{121.95, 30.85}, {122.3, 30.0}, {121.9, 29.0}, {121.2, 27.8}, {120.4, 26.9},
+		{119.9, 25.9}, {119.8, 25.5}, {118.8, 24.8}, {118.25, 24.4}, {117.5, 23.7},
+		// 南海沿岸，港澳在下面作为洞排除
+		{116.9, 23.25}, {115.4, 22.7}, {114.55, 22.5}, {114.5, 22.1}, {113.5, 22.0},
+		{112.8, 21.7}, {111.9, 21.5}, {110.2, 20.2}, {109.7, 20.9}, {109.1, 21.4},
+		{108.6, 21.7}, {108.0, 21.5},
+		// 越南、老挝、缅甸
+		{106.7, 22.0}, {106.7, 22.6}, {105.3, 23.3}, {104.3, 22.75}, {103.96, 22.48},
+		{103.5, 22.6}, {102.4, 22.7},
+		{101.8, 22.4}, {101.7, 21.2}, {101.2, 21.15}, {100.2, 21.5}, {99.2, 22.1},
+		{99.5, 22.9}, {98.7, 23.9}, {97.5, 24.0}, {97.7, 24.8}, {98.7, 26.0},
+		{98.3, 27.6}, {97.3, 28.2},
+		// 喜马拉雅山脉
+		{96.3, 29.0}, {95.5, 29.3}, {94.0, 28.9}, {92.0, 27.8}, {91.6, 27.9},
+		{89.6, 28.2}, {89.0, 27.3}, {88.8, 27.3}, {88.1, 27.9}, {86.9, 27.99},
+		{85.5, 28.3}, {83.5, 29.2}, {81.5, 30.2}, {80.2, 30.5}, {79.0, 31.3},
+		// 喀喇昆仑山脉、帕米尔高原、天山
+		{78.7, 32.7}, {78.8, 34.0}, {78.0, 35.0}, {77.8, 35.5}, {76.0, 36.6},
+		{75.4, 37.0}, {74.8, 37.2}, {74.9, 38.4}, {74.0, 38.6}, {73.6, 39.4},
+		{74.5, 40.1}, {75.6, 40.6}, {76.8, 41.0}, {78.0, 41.4}, {79.0, 41.8},
+		{80.2, 42.2}, {80.8, 43.2}, {80.4, 44.1}, {80.8, 45.0}, {82.3, 45.5},
+		{83.0, 47.2}, {85.5, 47.0}, {86.2, 49.1}, {87.35, 49.17},
+		// 蒙古
+		{88.0, 48.5}, {90.0, 47.9}, {90.9, 46.5}, {91.0, 45.5}, {93.5, 45.0},
+		{95.5, 44.2}, {96.4, 42.7}, {100.0, 42.6}, {104.5, 41.8}, {105.0, 41.6},
+		{107.0, 42.3}, {109.0, 42.5}, {110.5, 42.7}, {111.95, 43.7}, {113.6, 44.8},
+		{115.5, 45.5}, {116.7, 46.4}, {118.0, 46.7}, {119.8, 46.7}, {119.7, 47.7},
+		{118.5, 48.0}, {117.4, 47.7}, {116.0, 47.85}, {115.6, 47.9},
+		// 额尔古纳河、黑龙江
+		{116.7, 49.85}, {117.9, 49.55}, {119.2, 50.3}, {120.0, 51.5}, {120.8, 52.5},
+		{122.3, 53.45}, {123.6, 53.55}, {125.5, 53.1}, {126.6, 51.8}, {127.3, 50.7},
+		{127.6, 50.2}, {129.5, 49.4}, {130.7, 48.9}, {132.5, 47.7}, {133.6, 48.2},
+		{134.4, 48.45},
+	})
+	hongKong := toRing([][2]float64{
+		{113.82, 22.18}, {113.87, 22.40}, {113.96, 22.47}, {114.03, 22.50}, {114.10, 22.53},
+		{114.18, 22.56}, {114.26, 22.56}, {114.50, 22.45}, {114.50, 22.14}, {113.82, 22.14},
+	})
+	macau := toRing([][2]float64{
+		{113.53, 22.11}, {113.60, 22.11}, {113.60, 22.215}, {113.53, 22.215},
+	})
+	hainan := toRing([][2]float64{
+		{108.6, 19.1}, {109.6, 20.0}, {110.3, 20.25}, {110.7, 20.15}, {111.1, 19.7},
+		{110.3, 18.1}, {109.5, 18.1}, {108.6, 18.5},
+	})
+	return MultiPolygon{{mainland, hongKong, macau}, {hainan}}
+}()
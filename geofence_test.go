@@ -0,0 +1,222 @@
+package is
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMainlandChina(t *testing.T) {
+	inside := []struct {
+		name string
+		c    Coordinate
+	}{
+		{"北京", Coordinate{39.9042, 116.4074}},
+		{"天津", Coordinate{39.0842, 117.2010}},
+		{"上海", Coordinate{31.2304, 121.4737}},
+		{"重庆", Coordinate{29.5630, 106.5516}},
+		{"广州", Coordinate{23.1291, 113.2644}},
+		{"深圳", Coordinate{22.5431, 114.0579}},
+		{"珠海", Coordinate{22.2710, 113.5767}},
+		{"成都", Coordinate{30.5728, 104.0668}},
+		{"武汉", Coordinate{30.5928, 114.3055}},
+		{"西安", Coordinate{34.3416, 108.9398}},
+		{"哈尔滨", Coordinate{45.8038, 126.5350}},
+		{"呼和浩特", Coordinate{40.8424, 111.7490}},
+		{"乌鲁木齐", Coordinate{43.8256, 87.6168}},
+		{"拉萨", Coordinate{29.6520, 91.1721}},
+		{"昆明", Coordinate{25.0389, 102.7183}},
+		{"南宁", Coordinate{22.8170, 108.3665}},
+		{"青岛", Coordinate{36.0671, 120.3826}},
+		{"烟台", Coordinate{37.4638, 121.4479}},
+		{"大连", Coordinate{38.9140, 121.6147}},
+		{"宁波", Coordinate{29.8683, 121.5440}},
+		{"福州", Coordinate{26.0745, 119.2965}},
+		{"厦门", Coordinate{24.4798, 118.0894}},
+		{"汕头", Coordinate{23.3540, 116.6822}},
+		{"湛江", Coordinate{21.2707, 110.3594}},
+		{"北海", Coordinate{21.4811, 109.1200}},
+		{"海口", Coordinate{20.0440, 110.3417}},
+		{"文昌", Coordinate{19.5430, 110.7980}},
+		{"儋州", Coordinate{19.5210, 109.5810}},
+		{"三亚", Coordinate{18.2528, 109.5120}},
+		{"东兴", Coordinate{21.5475, 107.9723}},
+		{"凭祥", Coordinate{22.0940, 106.7560}},
+		{"河口", Coordinate{22.5083, 103.9393}},
+		{"景洪", Coordinate{22.0094, 100.7975}},
+		{"瑞丽", Coordinate{24.0128, 97.8519}},
+		{"狮泉河", Coordinate{32.5000, 80.1000}},
+		{"和田", Coordinate{37.1100, 79.9200}},
+		{"喀什", Coordinate{39.4704, 75.9898}},
+		{"伊宁", Coordinate{43.9100, 81.3200}},
+		{"阿勒泰", Coordinate{47.8400, 88.1400}},
+		{"二连浩特", Coordinate{43.6530, 111.9770}},
+		{"满洲里", Coordinate{49.5978, 117.3786}},
+		{"漠河", Coordinate{52.9720, 122.5382}},
+		{"黑河", Coordinate{50.2454, 127.5289}},
+		{"抚远", Coordinate{48.3647, 134.2910}},
+		{"绥芬河", Coordinate{44.3964, 131.1532}},
+		{"东宁", Coordinate{44.0600, 131.1200}},
+		{"珲春", Coordinate{42.8678, 130.3648}},
+		{"丹东", Coordinate{40.0004, 124.3545}},
+	}
+	for _, tt := range inside {
+		if !MainlandChina(tt.c) {
+			t.Errorf("MainlandChina(%s %v) = false, want true", tt.name, tt.c)
+		}
+	}
+	outside := []struct {
+		name string
+		c    Coordinate
+	}{
+		{"香港", Coordinate{22.3193, 114.1694}},
+		{"澳门", Coordinate{22.1987, 113.5439}},
+		{"台北", Coordinate{25.0330, 121.5654}},
+		{"高雄", Coordinate{22.6273, 120.3014}},
+		{"乌兰巴托", Coordinate{47.8864, 106.9057}},
+		{"符拉迪沃斯托克", Coordinate{43.1155, 131.8855}},
+		{"乌苏里斯克", Coordinate{43.8000, 131.9500}},
+		{"波格拉尼奇内", Coordinate{44.4100, 131.3800}},
+		{"哈巴罗夫斯克", Coordinate{48.4800, 135.0700}},
+		{"平壤", Coordinate{39.0392, 125.7625}},
+		{"首尔", Coordinate{37.5665, 126.9780}},
+		{"河内", Coordinate{21.0278, 105.8342}},
+		{"加德满都", Coordinate{27.7172, 85.3240}},
+		{"阿拉木图", Coordinate{43.2220, 76.8512}},
+		{"东京", Coordinate{35.6762, 139.6503}},
+		{"黄海", Coordinate{35.0000, 123.0000}},
+		{"南海", Coordinate{18.0000, 112.0000}},
+	}
+	for _, tt := range outside {
+		if MainlandChina(tt.c) {
+			t.Errorf("MainlandChina(%s %v) = true, want false", tt.name, tt.c)
+		}
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	box := BoundingBox{MinLat: 30, MinLng: 110, MaxLat: 40, MaxLng: 120}
+	pacific := BoundingBox{MinLat: -20, MinLng: 170, MaxLat: 20, MaxLng: -170}
+	tests := []struct {
+		box  BoundingBox
+		c    Coordinate
+		want bool
+	}{
+		{box, Coordinate{35, 115}, true},
+		{box, Coordinate{30, 110}, true},
+		{box, Coordinate{40, 120}, true},
+		{box, Coordinate{29.99, 115}, false},
+		{box, Coordinate{35, 120.01}, false},
+		{pacific, Coordinate{0, 179}, true},
+		{pacific, Coordinate{0, -175}, true},
+		{pacific, Coordinate{0, 180}, true},
+		{pacific, Coordinate{0, 0}, false},
+		{pacific, Coordinate{21, 175}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.box.Contains(tt.c); got != tt.want {
+			t.Errorf("%+v.Contains(%v) = %v, want %v", tt.box, tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	beijing := Coordinate{39.9042, 116.4074}
+	shanghai := Coordinate{31.2304, 121.4737}
+	if d := Distance(beijing, shanghai); math.Abs(d-1067e3) > 5e3 {
+		t.Errorf("Distance(北京, 上海) = %.0f, want about 1067 km", d)
+	}
+	if d := Distance(beijing, beijing); d != 0 {
+		t.Errorf("Distance(北京, 北京) = %v, want 0", d)
+	}
+	// 跨越 180 度经线时取较短的大圆距离
+	if d := Distance(Coordinate{0, 179.5}, Coordinate{0, -179.5}); math.Abs(d-111195) > 10 {
+		t.Errorf("Distance across the antimeridian = %.0f, want about 111195", d)
+	}
+	if d := Distance(Coordinate{90, 0}, Coordinate{-90, 0}); math.Abs(d-math.Pi*earthRadius) > 1 {
+		t.Errorf("Distance(north pole, south pole) = %.0f, want %.0f", d, math.Pi*earthRadius)
+	}
+	if !WithinRadius(Coordinate{39.9087, 116.3975}, beijing, 1000) {
+		t.Error("天安门 should be within 1 km of 北京")
+	}
+	if WithinRadius(shanghai, beijing, 1000e3) {
+		t.Error("上海 should not be within 1000 km of 北京")
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	// 10x10 的正方形，中间挖去 4x4 的洞，第二个多边形与之不相交
+	square := Polygon{
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		{{3, 3}, {3, 7}, {7, 7}, {7, 3}},
+	}
+	other := Polygon{{{20, 20}, {20, 30}, {30, 30}}}
+	m := MultiPolygon{square, other}
+	tests := []struct {
+		c    Coordinate
+		want bool
+	}{
+		{Coordinate{1, 1}, true},
+		{Coordinate{0, 5}, true},
+		{Coordinate{10, 10}, true},
+		{Coordinate{5, 5}, false},
+		{Coordinate{3, 5}, true},
+		{Coordinate{-1, 5}, false},
+		{Coordinate{11, 5}, false},
+		{Coordinate{22, 25}, true},
+		{Coordinate{28, 25}, false},
+	}
+	for _, tt := range tests {
+		if got := m.Contains(tt.c); got != tt.want {
+			t.Errorf("MultiPolygon.Contains(%v) = %v, want %v", tt.c, got, tt.want)
+		}
+	}
+	if (Polygon{}).Contains(Coordinate{}) {
+		t.Error("empty polygon should not contain any point")
+	}
+}
+
+func TestParseGeofence(t *testing.T) {
+	const feature = `{"type":"FeatureCollection","features":[
+		{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[116,39],[117,39],[117,40],[116,40],[116,39]]]}},
+		{"type":"Feature","properties":{},"geometry":{"type":"MultiPolygon","coordinates":[[[[121,31],[122,31],[122,32],[121,32],[121,31]]]]}}
+	]}`
+	fence, err := ParseGeofence([]byte(feature))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterGeofence("test-cities", fence)
+	tests := []struct {
+		c    Coordinate
+		want bool
+	}{
+		{Coordinate{39.9042, 116.4074}, true},
+		{Coordinate{31.2304, 121.4737}, true},
+		{Coordinate{23.1291, 113.2644}, false},
+	}
+	for _, tt := range tests {
+		if got := InGeofence(tt.c, "test-cities"); got != tt.want {
+			t.Errorf("InGeofence(%v) = %v, want %v", tt.c, got, tt.want)
+		}
+	}
+	if InGeofence(Coordinate{39.9042, 116.4074}, "no-such-fence") {
+		t.Error("InGeofence should be false for an unregistered fence")
+	}
+
+	invalid := []struct {
+		data string
+		want error
+	}{
+		{`{"type":"Point","coordinates":[116,39]}`, ErrBadGeoJSON},
+		{`{"type":"Polygon","coordinates":[]}`, ErrBadGeoJSON},
+		{`{"type":"Polygon","coordinates":[[[116,39],[117,39],[116,39]]]}`, ErrBadGeoJSON},
+		{`{"type":"Polygon","coordinates":[[[116,39],[117,39],[117],[116,39]]]}`, ErrBadGeoJSON},
+		{`{"type":"Polygon","coordinates":[[[116,91],[117,39],[117,40],[116,91]]]}`, ErrCoordinateRange},
+		{`{"type":"Feature","geometry":null}`, ErrBadGeoJSON},
+		{`not json`, ErrBadGeoJSON},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseGeofence([]byte(tt.data)); err != tt.want {
+			t.Errorf("ParseGeofence(%s) = %v, want %v", tt.data, err, tt.want)
+		}
+	}
+}
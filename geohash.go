@@ -0,0 +1,71 @@
+package is
+
+import "errors"
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+var (
+	ErrBadGeohash = errors.New("geohash: invalid syntax")
+
+	geohashDecoding = alphabetDecoding(geohashAlphabet)
+)
+
+// maxGeohashLength 是 geohash 的最大长度，更长的 geohash 超出了 float64 的精度
+const maxGeohashLength = 22
+
+// DecodeGeohash 将 geohash 解码为其表示的矩形区域，不区分大小写
+func DecodeGeohash(s string) (BoundingBox, error) {
+	if s == "" || len(s) > maxGeohashLength {
+		return BoundingBox{}, ErrBadGeohash
+	}
+	box := BoundingBox{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180}
+	even := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			c |= 0x20
+		}
+		d := geohashDecoding[c]
+		if d == 0xff {
+			return BoundingBox{}, ErrBadGeohash
+		}
+		// 每个字符 5 位，从经度开始交替表示经度与纬度的二分
+		for bit := 4; bit >= 0; bit-- {
+			set := d>>bit&1 == 1
+			if even {
+				mid := (box.MinLng + box.MaxLng) / 2
+				if set {
+					box.MinLng = mid
+				} else {
+					box.MaxLng = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if set {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return box, nil
+}
+
+// Geohash 判断给出的字符串是否为有效的 geohash，且长度（精度）在 minPrecision 与
+// maxPrecision 之间，maxPrecision 为 0 时只受最大长度 22 的限制。
+func Geohash(s string, minPrecision, maxPrecision int) bool {
+	if len(s) < minPrecision || (maxPrecision > 0 && len(s) > maxPrecision) {
+		return false
+	}
+	_, err := DecodeGeohash(s)
+	return err == nil
+}
+
+// GeohashContains 判断坐标是否位于 geohash 表示的矩形区域内，
+// 常用于校验客户端上报的坐标与 geohash 是否一致。
+func GeohashContains(s string, c Coordinate) bool {
+	box, err := DecodeGeohash(s)
+	return err == nil && box.Contains(c)
+}
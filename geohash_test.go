@@ -0,0 +1,77 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDecodeGeohash(t *testing.T) {
+	tests := []struct {
+		s    string
+		want BoundingBox
+	}{
+		{"s", BoundingBox{MinLat: 0, MaxLat: 45, MinLng: 0, MaxLng: 45}},
+		{"0", BoundingBox{MinLat: -90, MaxLat: -45, MinLng: -180, MaxLng: -135}},
+		{"z", BoundingBox{MinLat: 45, MaxLat: 90, MinLng: 135, MaxLng: 180}},
+		{"ezs42", BoundingBox{MinLat: 42.5830078125, MaxLat: 42.626953125, MinLng: -5.625, MaxLng: -5.5810546875}},
+		{"EZS42", BoundingBox{MinLat: 42.5830078125, MaxLat: 42.626953125, MinLng: -5.625, MaxLng: -5.5810546875}},
+	}
+	for _, tt := range tests {
+		box, err := DecodeGeohash(tt.s)
+		if err != nil {
+			t.Errorf("DecodeGeohash(%q) = %v", tt.s, err)
+		} else if box != tt.want {
+			t.Errorf("DecodeGeohash(%q) = %+v, want %+v", tt.s, box, tt.want)
+		}
+	}
+
+	// 每增加一个字符，区域的宽高交替缩小为 1/8 与 1/4
+	box, _ := DecodeGeohash("wx4g0ec1")
+	if h, w := box.MaxLat-box.MinLat, box.MaxLng-box.MinLng; h != 180/math.Pow(2, 20) || w != 360/math.Pow(2, 20) {
+		t.Errorf("DecodeGeohash(wx4g0ec1) size = %v x %v", h, w)
+	}
+	if !box.Contains(Coordinate{39.9232, 116.3907}) {
+		t.Errorf("DecodeGeohash(wx4g0ec1) = %+v, want it to contain (39.9232, 116.3907)", box)
+	}
+
+	for _, s := range []string{"", "a", "i", "l", "o", "ezs4a", "wx4I", "wx4g-", "wx4g ", strings.Repeat("s", 23)} {
+		if _, err := DecodeGeohash(s); !errors.Is(err, ErrBadGeohash) {
+			t.Errorf("DecodeGeohash(%q) = %v, want ErrBadGeohash", s, err)
+		}
+	}
+	if _, err := DecodeGeohash(strings.Repeat("s", 22)); err != nil {
+		t.Errorf("DecodeGeohash(22 chars) = %v", err)
+	}
+}
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		s        string
+		min, max int
+		want     bool
+	}{
+		{"wx4g0ec1", 0, 0, true},
+		{"wx4g0ec1", 8, 8, true},
+		{"wx4g0ec1", 9, 0, false},
+		{"wx4g0ec1", 1, 7, false},
+		{"wx4g0ecl", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		if got := Geohash(tt.s, tt.min, tt.max); got != tt.want {
+			t.Errorf("Geohash(%q, %d, %d) = %v, want %v", tt.s, tt.min, tt.max, got, tt.want)
+		}
+	}
+
+	if !GeohashContains("ezs42", Coordinate{42.605, -5.603}) {
+		t.Error("GeohashContains(ezs42) = false for its center")
+	}
+	if !GeohashContains("ezs42", Coordinate{42.626953125, -5.625}) {
+		t.Error("GeohashContains(ezs42) = false on its boundary")
+	}
+	if GeohashContains("ezs42", Coordinate{42.7, -5.603}) || GeohashContains("ezs4a", Coordinate{42.605, -5.603}) {
+		t.Error("GeohashContains accepted a coordinate outside the cell or an invalid geohash")
+	}
+}
@@ -0,0 +1,243 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrGeoJSONType     = errors.New("geojson: unexpected type")
+	ErrGeoJSONPosition = errors.New("geojson: invalid position")
+	ErrGeoJSONRing     = errors.New("geojson: linear ring must be closed and have at least four positions")
+	ErrGeoJSONWinding  = errors.New("geojson: polygon does not follow the right-hand rule")
+)
+
+// GeoJSONOptions 定义 GeoJSON 校验的策略，零值接受任意类型的对象
+type GeoJSONOptions struct {
+	// Types 允许的顶层对象类型，如 "Polygon"、"Feature"，为空时不限制
+	Types []string
+	// RightHandRule 要求多边形的外环为逆时针方向、内环为顺时针方向（RFC 7946 第 3.1.6 节）
+	RightHandRule bool
+}
+
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Properties  json.RawMessage   `json:"properties"`
+	Features    []json.RawMessage `json:"features"`
+	ID          json.RawMessage   `json:"id"`
+	BBox        []float64         `json:"bbox"`
+}
+
+// CheckGeoJSON 按照 RFC 7946 校验 GeoJSON 对象，包括：
+//   - 对象类型及其必需的成员
+//   - 位置由两个或三个数值组成，经纬度在有效范围内
+//   - LineString 至少有两个位置，多边形的环是闭合的且至少有四个位置
+//   - Feature 的 "id" 为字符串或数值
+//   - "bbox" 的长度为 4 或 6，经纬度在有效范围内且南边界不大于北边界，
+//     西边界大于东边界表示跨越 180° 经线（RFC 7946 第 5.2 节）
+func CheckGeoJSON(data []byte, opts GeoJSONOptions) error {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return ErrBadGeoJSON
+	}
+	if len(opts.Types) > 0 && !slices.Contains(opts.Types, obj.Type) {
+		return ErrGeoJSONType
+	}
+	return checkGeoJSONObject(obj, opts)
+}
+
+// GeoJSON 判断给出的字符串是否为有效的 GeoJSON 对象
+func GeoJSON(s string) bool {
+	return CheckGeoJSON([]byte(s), GeoJSONOptions{}) == nil
+}
+
+func checkGeoJSONObject(obj geoJSONObject, opts GeoJSONOptions) error {
+	if obj.BBox != nil && !validBBox(obj.BBox) {
+		return ErrBadGeoJSON
+	}
+	switch obj.Type {
+	case "Feature":
+		if obj.Properties == nil || (!isJSONNull(obj.Properties) && obj.Properties[0] != '{') {
+			return ErrBadGeoJSON
+		}
+		if obj.ID != nil && obj.ID[0] != '"' && !isJSONNumber(obj.ID) {
+			return ErrBadGeoJSON
+		}
+		if obj.Geometry == nil {
+			return ErrBadGeoJSON
+		}
+		if isJSONNull(obj.Geometry) {
+			return nil
+		}
+		return checkGeoJSONChild(obj.Geometry, opts, isGeometryType)
+	case "FeatureCollection":
+		if obj.Features == nil {
+			return ErrBadGeoJSON
+		}
+		for _, f := range obj.Features {
+			if err := checkGeoJSONChild(f, opts, func(t string) bool { return t == "Feature" }); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "GeometryCollection":
+		if obj.Geometries == nil {
+			return ErrBadGeoJSON
+		}
+		for _, g := range obj.Geometries {
+			if err := checkGeoJSONChild(g, opts, isGeometryType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkGeometry(obj.Type, obj.Coordinates, opts)
+}
+
+// validBBox 校验 [west, south, east, north] 或 [west, south, low, east, north, high]
+// 形式的 bbox，跨越 180° 经线时 west 大于 east
+func validBBox(bbox []float64) bool {
+	var west, south, east, north float64
+	switch len(bbox) {
+	case 4:
+		west, south, east, north = bbox[0], bbox[1], bbox[2], bbox[3]
+	case 6:
+		if bbox[2] > bbox[5] {
+			return false
+		}
+		west, south, east, north = bbox[0], bbox[1], bbox[3], bbox[4]
+	default:
+		return false
+	}
+	return Longitude(west) && Longitude(east) && Latitude(south) && Latitude(north) && south <= north
+}
+
+func checkGeoJSONChild(data json.RawMessage, opts GeoJSONOptions, allowed func(string) bool) error {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return ErrBadGeoJSON
+	}
+	if !allowed(obj.Type) {
+		return ErrGeoJSONType
+	}
+	return checkGeoJSONObject(obj, opts)
+}
+
+func isGeometryType(t string) bool {
+	switch t {
+	case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon", "GeometryCollection":
+		return true
+	}
+	return false
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(raw, []byte("null"))
+}
+
+func isJSONNumber(raw json.RawMessage) bool {
+	var n json.Number
+	return json.Unmarshal(raw, &n) == nil && raw[0] != '"'
+}
+
+// checkGeometry 校验除 GeometryCollection 以外的几何对象的坐标
+func checkGeometry(typ string, coordinates json.RawMessage, opts GeoJSONOptions) error {
+	if coordinates == nil {
+		return ErrBadGeoJSON
+	}
+	var err error
+	switch typ {
+	case "Point":
+		var pos []float64
+		if err = json.Unmarshal(coordinates, &pos); err == nil {
+			return checkPositions([][]float64{pos}, 1)
+		}
+	case "MultiPoint":
+		var line [][]float64
+		if err = json.Unmarshal(coordinates, &line); err == nil {
+			return checkPositions(line, 0)
+		}
+	case "LineString":
+		var line [][]float64
+		if err = json.Unmarshal(coordinates, &line); err == nil {
+			return checkPositions(line, 2)
+		}
+	case "MultiLineString":
+		var lines [][][]float64
+		if err = json.Unmarshal(coordinates, &lines); err == nil {
+			for _, line := range lines {
+				if err = checkPositions(line, 2); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err = json.Unmarshal(coordinates, &rings); err == nil {
+			return checkRings(rings, opts)
+		}
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err = json.Unmarshal(coordinates, &polygons); err == nil {
+			for _, rings := range polygons {
+				if err = checkRings(rings, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	default:
+		return ErrGeoJSONType
+	}
+	return ErrBadGeoJSON
+}
+
+// checkPositions 校验位置列表，minCount 为最少的位置个数
+func checkPositions(positions [][]float64, minCount int) error {
+	if len(positions) < minCount {
+		return ErrBadGeoJSON
+	}
+	for _, pos := range positions {
+		if len(pos) != 2 && len(pos) != 3 {
+			return ErrGeoJSONPosition
+		}
+		if !Longitude(pos[0]) || !Latitude(pos[1]) {
+			return ErrGeoJSONPosition
+		}
+	}
+	return nil
+}
+
+func checkRings(rings [][][]float64, opts GeoJSONOptions) error {
+	for i, ring := range rings {
+		if err := checkPositions(ring, 4); err != nil {
+			if err == ErrBadGeoJSON {
+				return ErrGeoJSONRing
+			}
+			return err
+		}
+		if !slices.Equal(ring[0], ring[len(ring)-1]) {
+			return ErrGeoJSONRing
+		}
+		// 外环的有向面积为正（逆时针），内环为负（顺时针）
+		if opts.RightHandRule && (ringArea(ring) > 0) != (i == 0) {
+			return ErrGeoJSONWinding
+		}
+	}
+	return nil
+}
+
+// ringArea 返回以经度为 x、纬度为 y 的环的有向面积的两倍，逆时针为正
+func ringArea(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area
+}
@@ -0,0 +1,82 @@
+package is
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckGeoJSON(t *testing.T) {
+	const square = `[[[0,0],[10,0],[10,10],[0,10],[0,0]]]`
+	tests := []struct {
+		s    string
+		opts GeoJSONOptions
+		want error
+	}{
+		{`{"type":"Point","coordinates":[116.4,39.9]}`, GeoJSONOptions{}, nil},
+		{`{"type":"Point","coordinates":[116.4,39.9,50]}`, GeoJSONOptions{}, nil},
+		{`{"type":"Point","coordinates":[116.4]}`, GeoJSONOptions{}, ErrGeoJSONPosition},
+		{`{"type":"Point","coordinates":[116.4,39.9,50,1]}`, GeoJSONOptions{}, ErrGeoJSONPosition},
+		{`{"type":"Point","coordinates":[39.9,116.4]}`, GeoJSONOptions{}, ErrGeoJSONPosition},
+		{`{"type":"Point","coordinates":[181,0]}`, GeoJSONOptions{}, ErrGeoJSONPosition},
+		{`{"type":"Point"}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":"0,0"}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"MultiPoint","coordinates":[]}`, GeoJSONOptions{}, nil},
+		{`{"type":"LineString","coordinates":[[0,0]]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"LineString","coordinates":[[0,0],[1,1]]}`, GeoJSONOptions{}, nil},
+		// 跨越 180° 经线的线段，经度各自在有效范围内
+		{`{"type":"LineString","coordinates":[[179.5,0],[-179.5,0]]}`, GeoJSONOptions{}, nil},
+		{`{"type":"MultiLineString","coordinates":[[[0,0],[1,1]],[[2,2]]]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+
+		// 多边形的环
+		{`{"type":"Polygon","coordinates":` + square + `}`, GeoJSONOptions{}, nil},
+		{`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10]]]}`, GeoJSONOptions{}, ErrGeoJSONRing},
+		{`{"type":"Polygon","coordinates":[[[0,0],[10,0],[0,0]]]}`, GeoJSONOptions{}, ErrGeoJSONRing},
+		{`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0,5]]]}`, GeoJSONOptions{}, ErrGeoJSONRing},
+		{`{"type":"Polygon","coordinates":` + square + `}`, GeoJSONOptions{RightHandRule: true}, nil},
+		{`{"type":"Polygon","coordinates":[[[0,0],[0,10],[10,10],[10,0],[0,0]]]}`, GeoJSONOptions{RightHandRule: true}, ErrGeoJSONWinding},
+		{`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[2,4],[4,4],[4,2],[2,2]]]}`, GeoJSONOptions{RightHandRule: true}, nil},
+		{`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[4,2],[4,4],[2,4],[2,2]]]}`, GeoJSONOptions{RightHandRule: true}, ErrGeoJSONWinding},
+		{`{"type":"MultiPolygon","coordinates":[` + square + `,[[[0,0],[1,0],[0,0]]]]}`, GeoJSONOptions{}, ErrGeoJSONRing},
+
+		// bbox，西边界大于东边界表示跨越 180° 经线
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,-10,10,10]}`, GeoJSONOptions{}, nil},
+		{`{"type":"Point","coordinates":[179,-18],"bbox":[177,-20,-178,-16]}`, GeoJSONOptions{}, nil},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,-10,0,10,10,100]}`, GeoJSONOptions{}, nil},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,-10,100,10,10,0]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,10,10,-10]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-190,-10,10,10]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,-91,10,10]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":[0,0],"bbox":[-10,-10,10]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Point","coordinates":[0,0],"bbox":["a","b","c","d"]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+
+		// Feature 与集合
+		{`{"type":"Feature","geometry":null,"properties":null}`, GeoJSONOptions{}, nil},
+		{`{"type":"Feature","id":"a1","geometry":{"type":"Point","coordinates":[0,0]},"properties":{"name":"x"}}`, GeoJSONOptions{}, nil},
+		{`{"type":"Feature","id":7,"geometry":null,"properties":{}}`, GeoJSONOptions{}, nil},
+		{`{"type":"Feature","id":true,"geometry":null,"properties":{}}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Feature","geometry":null}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Feature","properties":{}}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Feature","geometry":null,"properties":[]}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"Feature","geometry":{"type":"Feature","geometry":null,"properties":null},"properties":null}`, GeoJSONOptions{}, ErrGeoJSONType},
+		{`{"type":"FeatureCollection","features":[]}`, GeoJSONOptions{}, nil},
+		{`{"type":"FeatureCollection","features":[{"type":"Point","coordinates":[0,0]}]}`, GeoJSONOptions{}, ErrGeoJSONType},
+		{`{"type":"FeatureCollection"}`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]},{"type":"GeometryCollection","geometries":[]}]}`, GeoJSONOptions{}, nil},
+		{`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,91]}]}`, GeoJSONOptions{}, ErrGeoJSONPosition},
+
+		// 类型限制与无效输入
+		{`{"type":"Point","coordinates":[0,0]}`, GeoJSONOptions{Types: []string{"Polygon", "Feature"}}, ErrGeoJSONType},
+		{`{"type":"Circle","coordinates":[0,0]}`, GeoJSONOptions{}, ErrGeoJSONType},
+		{`{"type":"point","coordinates":[0,0]}`, GeoJSONOptions{}, ErrGeoJSONType},
+		{`[]`, GeoJSONOptions{}, ErrBadGeoJSON},
+		{``, GeoJSONOptions{}, ErrBadGeoJSON},
+	}
+	for _, tt := range tests {
+		if err := CheckGeoJSON([]byte(tt.s), tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckGeoJSON(%s) = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+	if !GeoJSON(`{"type":"Point","coordinates":[0,0]}`) || GeoJSON(`{}`) {
+		t.Error("GeoJSON does not match CheckGeoJSON")
+	}
+}
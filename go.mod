@@ -1,5 +1,3 @@
 module zestack.dev/is
 
 go 1.21.0
-
-toolchain go1.21.0
@@ -0,0 +1,159 @@
+package is
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// hashCatalog 是已知的摘要算法及其摘要长度（字节），IdentifyHash 按此顺序返回候选算法
+var hashCatalog = []struct {
+	name string
+	size int
+}{
+	{"crc32", 4},
+	{"crc64", 8},
+	{"md4", 16},
+	{"md5", 16},
+	{"sha1", 20},
+	{"ripemd160", 20},
+	{"sha224", 28},
+	{"sha512-224", 28},
+	{"sha3-224", 28},
+	{"sha256", 32},
+	{"sha512-256", 32},
+	{"sha3-256", 32},
+	{"blake2s-256", 32},
+	{"blake2b-256", 32},
+	{"blake3", 32},
+	{"sm3", 32},
+	{"sha384", 48},
+	{"sha3-384", 48},
+	{"blake2b-384", 48},
+	{"sha512", 64},
+	{"sha3-512", 64},
+	{"blake2b-512", 64},
+}
+
+// HashSize 返回摘要算法的摘要长度（字节），算法名不区分大小写，
+// 并接受 "SHA-256"、"sha3_256"、"sha512/256" 这样的写法，未知算法返回 0。
+func HashSize(algo string) int {
+	algo = normalizeHashName(algo)
+	for _, h := range hashCatalog {
+		if h.name == algo {
+			return h.size
+		}
+	}
+	return 0
+}
+
+func normalizeHashName(algo string) string {
+	algo = strings.ToLower(algo)
+	algo = strings.NewReplacer("_", "-", "/", "-").Replace(algo)
+	if rest, ok := strings.CutPrefix(algo, "sha-"); ok {
+		algo = "sha" + rest
+	}
+	return algo
+}
+
+// Hash 判断给出的字符串是否为指定算法的摘要，摘要可以是任意大小写的十六进制编码、
+// base64 编码（标准或 URL 安全字母表，填充可选），也可以是带算法前缀的
+// SRI 形式（"sha384-<base64>"）或 OCI 形式（"sha256:<hex>"），此时前缀必须与 algo 一致。
+func Hash(s, algo string) bool {
+	size := HashSize(algo)
+	if size == 0 {
+		return false
+	}
+	algo = normalizeHashName(algo)
+	if prefix, digest, ok := cutHashPrefix(s); ok {
+		if normalizeHashName(prefix) != algo {
+			return false
+		}
+		s = digest
+	}
+	return decodedDigestSize(s) == size
+}
+
+// IdentifyHash 返回可能产生给出摘要的候选算法，
+// 带前缀的摘要只返回前缀指定的算法（长度相符时），无法识别时返回 nil。
+func IdentifyHash(s string) []string {
+	size := decodedDigestSize(s)
+	if prefix, digest, ok := cutHashPrefix(s); ok {
+		if name := normalizeHashName(prefix); HashSize(name) > 0 && HashSize(name) == decodedDigestSize(digest) {
+			return []string{name}
+		}
+		return nil
+	}
+	var names []string
+	for _, h := range hashCatalog {
+		if h.size == size {
+			names = append(names, h.name)
+		}
+	}
+	return names
+}
+
+// cutHashPrefix 拆分 "sha256:<hex>" 与 "sha384-<base64>" 形式的摘要
+func cutHashPrefix(s string) (algo, digest string, ok bool) {
+	if algo, digest, ok = strings.Cut(s, ":"); ok {
+		return
+	}
+	// "sha512-256"、"sha3-256" 这样的算法名本身含有 "-"，因此从后向前查找，
+	// 以已知算法名最长的那个 "-" 分隔；hex 与标准 base64 摘要不含 "-"，
+	// base64url 摘要中的 "-" 不会与前面的部分组成已知算法名
+	for i := len(s) - 1; i > 0; i-- {
+		if s[i] == '-' && HashSize(s[:i]) > 0 {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return "", s, false
+}
+
+// decodedDigestSize 返回十六进制或 base64 编码的摘要解码后的长度，无法解码时返回 -1
+func decodedDigestSize(s string) int {
+	if b, err := hex.DecodeString(s); err == nil && len(b) > 0 {
+		return len(b)
+	}
+	if strings.ContainsAny(s, "\r\n") {
+		return -1
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if b, err := enc.Strict().DecodeString(s); err == nil && len(b) > 0 {
+			return len(b)
+		}
+	}
+	return -1
+}
+
+// SRI 判断给出的字符串是否为有效的子资源完整性（Subresource Integrity）元数据，
+// 即以空白分隔的一个或多个 "sha256-"、"sha384-"、"sha512-" 加 base64 摘要，
+// 每项可以带有以 "?" 开始的选项。
+func SRI(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, field := range fields {
+		field, _, _ = strings.Cut(field, "?")
+		algo, digest, ok := strings.Cut(field, "-")
+		if !ok || (algo != "sha256" && algo != "sha384" && algo != "sha512") {
+			return false
+		}
+		b, err := base64.StdEncoding.Strict().DecodeString(digest)
+		if err != nil || len(b) != HashSize(algo) {
+			return false
+		}
+	}
+	return true
+}
+
+// OCIDigest 判断给出的字符串是否为 OCI 镜像规范中的摘要，
+// 如 "sha256:" 加 64 位或 "sha512:" 加 128 位小写十六进制字符。
+func OCIDigest(s string) bool {
+	algo, digest, ok := strings.Cut(s, ":")
+	if !ok || (algo != "sha256" && algo != "sha512") || strings.ToLower(digest) != digest {
+		return false
+	}
+	b, err := hex.DecodeString(digest)
+	return err == nil && len(b) == HashSize(algo)
+}
@@ -0,0 +1,160 @@
+package is
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var (
+	sha256Empty = sha256.Sum256(nil)
+	sha384Empty = sha512.Sum384(nil)
+	sha512Empty = sha512.Sum512(nil)
+	sha1Size    = make([]byte, 20)
+)
+
+func TestHash(t *testing.T) {
+	hex256 := hex.EncodeToString(sha256Empty[:])
+	b64256 := base64.StdEncoding.EncodeToString(sha256Empty[:])
+	b64384 := base64.StdEncoding.EncodeToString(sha384Empty[:])
+	url256 := base64.RawURLEncoding.EncodeToString(sha256Empty[:])
+	tests := []struct {
+		s, algo string
+		want    bool
+	}{
+		{hex256, "sha256", true},
+		{strings.ToUpper(hex256), "SHA-256", true},
+		{hex256, "sha3_256", true},
+		{hex256, "sha512/256", true},
+		{hex256, "sha1", false},
+		{hex256[:62], "sha256", false},
+		{hex256 + "0", "sha256", false},
+		{b64256, "sha256", true},
+		{strings.TrimRight(b64256, "="), "sha256", true},
+		{url256, "sha256", true},
+		{hex256, "unknown", false},
+		{"", "sha256", false},
+
+		// 带前缀的形式，前缀必须与 algo 一致
+		{"sha256:" + hex256, "sha256", true},
+		{"sha256:" + hex256, "sha3-256", false},
+		{"sha384-" + b64384, "sha384", true},
+		{"sha384-" + b64384, "sha256", false},
+		{"SHA-256-" + b64256, "sha256", true},
+		{"sha512-256-" + b64256, "sha512-256", true},
+		{"sha3-256-" + b64256, "sha3-256", true},
+		{"sha3-256-" + b64256, "sha256", false},
+		{"sha256-" + url256, "sha256", true},
+		{"md5:" + hex256, "sha256", false},
+	}
+	for _, tt := range tests {
+		if got := Hash(tt.s, tt.algo); got != tt.want {
+			t.Errorf("Hash(%q, %q) = %v, want %v", tt.s, tt.algo, got, tt.want)
+		}
+	}
+}
+
+func TestHashSize(t *testing.T) {
+	tests := map[string]int{
+		"md5":        16,
+		"SHA1":       20,
+		"sha-384":    48,
+		"SHA3_512":   64,
+		"sha512/224": 28,
+		"blake3":     32,
+		"sha":        0,
+		"":           0,
+	}
+	for algo, want := range tests {
+		if got := HashSize(algo); got != want {
+			t.Errorf("HashSize(%q) = %d, want %d", algo, got, want)
+		}
+	}
+}
+
+func TestIdentifyHash(t *testing.T) {
+	hex256 := hex.EncodeToString(sha256Empty[:])
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{hex.EncodeToString(sha1Size), []string{"sha1", "ripemd160"}},
+		{hex256, []string{"sha256", "sha512-256", "sha3-256", "blake2s-256", "blake2b-256", "blake3", "sm3"}},
+		{base64.StdEncoding.EncodeToString(sha384Empty[:]), []string{"sha384", "sha3-384", "blake2b-384"}},
+		{"sha256:" + hex256, []string{"sha256"}},
+		{"SHA-256:" + hex256, []string{"sha256"}},
+		{"sha3-256-" + base64.StdEncoding.EncodeToString(sha256Empty[:]), []string{"sha3-256"}},
+		{"sha512:" + hex256, nil},
+		{"foo:" + hex256, nil},
+		{hex256[:10], nil},
+		{"not a hash", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := IdentifyHash(tt.s); !slices.Equal(got, tt.want) {
+			t.Errorf("IdentifyHash(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSRI(t *testing.T) {
+	s256 := "sha256-" + base64.StdEncoding.EncodeToString(sha256Empty[:])
+	s384 := "sha384-" + base64.StdEncoding.EncodeToString(sha384Empty[:])
+	s512 := "sha512-" + base64.StdEncoding.EncodeToString(sha512Empty[:])
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{s256, true},
+		{s384, true},
+		{s512, true},
+		{s256 + " " + s384 + " " + s512, true},
+		{"  " + s384 + "\t" + s512 + "\n", true},
+		{s384 + "?foo=bar", true},
+		{s384 + "?foo " + s256, true},
+		{s256 + " " + "sha1-" + base64.StdEncoding.EncodeToString(sha1Size), false},
+		{s256 + " sha384-", false},
+		{"sha384-" + base64.StdEncoding.EncodeToString(sha256Empty[:]), false},
+		{"sha256-" + base64.RawStdEncoding.EncodeToString(sha256Empty[:]), false},
+		{"sha256-" + base64.URLEncoding.EncodeToString(sha256Empty[:]), false},
+		{"SHA256-" + base64.StdEncoding.EncodeToString(sha256Empty[:]), false},
+		{"sha256:" + hex.EncodeToString(sha256Empty[:]), false},
+		{"", false},
+		{"   ", false},
+	}
+	for _, tt := range tests {
+		if got := SRI(tt.s); got != tt.want {
+			t.Errorf("SRI(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestOCIDigest(t *testing.T) {
+	hex256 := hex.EncodeToString(sha256Empty[:])
+	hex512 := hex.EncodeToString(sha512Empty[:])
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"sha256:" + hex256, true},
+		{"sha512:" + hex512, true},
+		{"sha256:" + strings.ToUpper(hex256), false},
+		{"sha256:" + hex512, false},
+		{"sha512:" + hex256, false},
+		{"sha384:" + hex.EncodeToString(sha384Empty[:]), false},
+		{"SHA256:" + hex256, false},
+		{"sha256-" + hex256, false},
+		{"sha256:" + hex256[:63], false},
+		{hex256, false},
+		{"sha256:", false},
+	}
+	for _, tt := range tests {
+		if got := OCIDigest(tt.s); got != tt.want {
+			t.Errorf("OCIDigest(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package is
+
+import (
+	"net/netip"
+	"strings"
+)
+
+var (
+	// 共享地址空间（运营商级 NAT），RFC 6598
+	cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+	// 文档示例地址，RFC 5737、RFC 3849、RFC 9637
+	documentationPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("3fff::/20"),
+	}
+
+	// IANA 特殊用途地址注册表中不可全局路由的地址段，
+	// 参考 RFC 6890 以及 https://www.iana.org/assignments/iana-ipv4-special-registry
+	reservedPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("192.88.99.0/24"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("::/128"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("64:ff9b:1::/48"),
+		netip.MustParsePrefix("100::/64"),
+		netip.MustParsePrefix("2001::/23"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("2002::/16"),
+		netip.MustParsePrefix("3fff::/20"),
+		netip.MustParsePrefix("5f00::/16"),
+		netip.MustParsePrefix("fc00::/7"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("ff00::/8"),
+	}
+)
+
+// parseAddr 解析 IP 地址，IPv4 映射的 IPv6 地址会被还原为 IPv4 地址，
+// 以便分类判断时 "::ffff:127.0.0.1" 与 "127.0.0.1" 得到相同的结果。
+func parseAddr(str string) (netip.Addr, bool) {
+	ip, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+func containsAddr(prefixes []netip.Prefix, ip netip.Addr) bool {
+	// 带区域标识的地址无法被 netip.Prefix 包含，判断前需要去掉
+	ip = ip.WithZone("")
+	for _, p := range prefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCGNATAddr(ip netip.Addr) bool {
+	return cgnatPrefix.Contains(ip.WithZone(""))
+}
+
+func isDocumentationAddr(ip netip.Addr) bool {
+	return containsAddr(documentationPrefixes, ip)
+}
+
+func isReservedAddr(ip netip.Addr) bool {
+	return containsAddr(reservedPrefixes, ip)
+}
+
+// StrictIP 判断给出的字符串是否为规范书写的 IP 地址，
+// 与 IP 相比，不允许 IPv6 地址的分组中出现前导零（如 "2001:0db8::1"）。
+func StrictIP(str string) bool {
+	ip, err := netip.ParseAddr(str)
+	if err != nil || ip.Zone() != "" {
+		return false
+	}
+	if ip.Is4() {
+		// netip 已经拒绝了带前导零的 IPv4 地址
+		return true
+	}
+	for _, group := range strings.Split(str, ":") {
+		if strings.Contains(group, ".") {
+			// 内嵌的 IPv4 部分同样由 netip 负责检查
+			continue
+		}
+		if len(group) > 1 && group[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// StrictIPv4 判断给出的字符串是否为规范书写的 IPv4 地址
+func StrictIPv4(str string) bool {
+	return StrictIP(str) && IPv4(str)
+}
+
+// StrictIPv6 判断给出的字符串是否为规范书写的 IPv6 地址
+func StrictIPv6(str string) bool {
+	return StrictIP(str) && IPv6(str)
+}
+
+// PrivateIP 判断给出的字符串是否为私有网络地址（RFC 1918、RFC 4193）
+func PrivateIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && ip.IsPrivate()
+}
+
+// LoopbackIP 判断给出的字符串是否为环回地址
+func LoopbackIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && ip.IsLoopback()
+}
+
+// LinkLocalIP 判断给出的字符串是否为链路本地单播地址
+func LinkLocalIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && ip.IsLinkLocalUnicast()
+}
+
+// MulticastIP 判断给出的字符串是否为组播地址
+func MulticastIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && ip.IsMulticast()
+}
+
+// CGNATIP 判断给出的字符串是否为运营商级 NAT 的共享地址（RFC 6598）
+func CGNATIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && isCGNATAddr(ip)
+}
+
+// DocumentationIP 判断给出的字符串是否为文档示例专用地址
+func DocumentationIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && isDocumentationAddr(ip)
+}
+
+// ReservedIP 判断给出的字符串是否属于 IANA 特殊用途地址，
+// 包括私有、环回、链路本地、组播、文档示例等不可全局路由的地址段。
+func ReservedIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && isReservedAddr(ip)
+}
+
+// GlobalIP 判断给出的字符串是否为可全局路由的公网地址
+func GlobalIP(str string) bool {
+	ip, ok := parseAddr(str)
+	return ok && !isReservedAddr(ip)
+}
@@ -0,0 +1,110 @@
+package is
+
+import "testing"
+
+func TestIP(t *testing.T) {
+	tests := []struct {
+		ip                         string
+		ip4, ip6, strict, anyValid bool
+	}{
+		{"192.0.2.1", true, false, true, true},
+		{"2001:db8::1", false, true, true, true},
+		{"::ffff:192.0.2.1", false, true, true, true},
+		{"2001:0db8::1", false, true, false, true},
+		{"fe80::1%eth0", false, false, false, false},
+		{"192.0.2.1%eth0", false, false, false, false},
+		{"192.000.2.1", false, false, false, false},
+		{"256.0.0.1", false, false, false, false},
+		{"1.2.3", false, false, false, false},
+		{"", false, false, false, false},
+		{"example.com", false, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := IPv4(tt.ip); got != tt.ip4 {
+			t.Errorf("IPv4(%q) = %v, want %v", tt.ip, got, tt.ip4)
+		}
+		if got := IPv6(tt.ip); got != tt.ip6 {
+			t.Errorf("IPv6(%q) = %v, want %v", tt.ip, got, tt.ip6)
+		}
+		if got := IP(tt.ip); got != tt.anyValid {
+			t.Errorf("IP(%q) = %v, want %v", tt.ip, got, tt.anyValid)
+		}
+		if got := StrictIP(tt.ip); got != tt.strict {
+			t.Errorf("StrictIP(%q) = %v, want %v", tt.ip, got, tt.strict)
+		}
+	}
+}
+
+func TestIPClassifiers(t *testing.T) {
+	const (
+		private = 1 << iota
+		loopback
+		linkLocal
+		multicast
+		cgnat
+		documentation
+		reserved
+		global
+	)
+	tests := []struct {
+		ip   string
+		want int
+	}{
+		{"10.1.2.3", private | reserved},
+		{"172.16.0.1", private | reserved},
+		{"172.32.0.1", global},
+		{"192.168.1.1", private | reserved},
+		{"fd12:3456::1", private | reserved},
+		{"127.0.0.1", loopback | reserved},
+		{"::1", loopback | reserved},
+		{"169.254.169.254", linkLocal | reserved},
+		{"fe80::1", linkLocal | reserved},
+		{"fe80::1%eth0", linkLocal | reserved},
+		{"224.0.0.1", multicast | reserved},
+		{"ff02::1", multicast | reserved},
+		{"100.64.0.1", cgnat | reserved},
+		{"100.128.0.1", global},
+		{"192.0.2.1", documentation | reserved},
+		{"198.51.100.7", documentation | reserved},
+		{"203.0.113.9", documentation | reserved},
+		{"2001:db8::1", documentation | reserved},
+		{"3fff::1", documentation | reserved},
+		{"0.0.0.0", reserved},
+		{"255.255.255.255", reserved},
+		{"198.18.0.1", reserved},
+		{"2002::1", reserved},
+		{"8.8.8.8", global},
+		{"1.1.1.1", global},
+		{"2606:4700:4700::1111", global},
+
+		// IPv4 映射的 IPv6 地址按 IPv4 地址分类
+		{"::ffff:10.0.0.1", private | reserved},
+		{"::ffff:127.0.0.1", loopback | reserved},
+		{"::ffff:100.64.0.1", cgnat | reserved},
+		{"::ffff:192.0.2.1", documentation | reserved},
+		{"::ffff:8.8.8.8", global},
+
+		{"not an ip", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		got := 0
+		for bit, fn := range map[int]func(string) bool{
+			private:       PrivateIP,
+			loopback:      LoopbackIP,
+			linkLocal:     LinkLocalIP,
+			multicast:     MulticastIP,
+			cgnat:         CGNATIP,
+			documentation: DocumentationIP,
+			reserved:      ReservedIP,
+			global:        GlobalIP,
+		} {
+			if fn(tt.ip) {
+				got |= bit
+			}
+		}
+		if got != tt.want {
+			t.Errorf("classify(%q) = %08b, want %08b", tt.ip, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package is
+
+import (
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+var (
+	ipSetsMu sync.RWMutex
+	ipSets   = map[string]*IPSet{}
+)
+
+// CIDR 判断给出的字符串是否为有效的 IPv4 或 IPv6 CIDR
+func CIDR(str string) bool {
+	_, err := netip.ParsePrefix(str)
+	return err == nil
+}
+
+// CIDRv4 判断给出的字符串是否为有效的 IPv4 CIDR
+func CIDRv4(str string) bool {
+	p, err := netip.ParsePrefix(str)
+	return err == nil && p.Addr().Is4()
+}
+
+// CIDRv6 判断给出的字符串是否为有效的 IPv6 CIDR
+func CIDRv6(str string) bool {
+	p, err := netip.ParsePrefix(str)
+	return err == nil && p.Addr().Is6()
+}
+
+// MaskedCIDR 判断给出的字符串是否为主机位全部为零的 CIDR，
+// 例如 "10.0.0.0/8" 有效，而 "10.0.0.1/8" 无效。
+func MaskedCIDR(str string) bool {
+	p, err := netip.ParsePrefix(str)
+	return err == nil && p == p.Masked()
+}
+
+// ParseIPRange 解析形如 "10.0.0.1-10.0.0.50" 的地址范围，
+// 两端必须属于同一地址族，且起始地址不能大于结束地址。
+func ParseIPRange(str string) (from, to netip.Addr, err error) {
+	a, b, ok := strings.Cut(str, "-")
+	if !ok {
+		return from, to, ErrBadRange
+	}
+	if from, err = netip.ParseAddr(strings.TrimSpace(a)); err != nil {
+		return
+	}
+	if to, err = netip.ParseAddr(strings.TrimSpace(b)); err != nil {
+		return
+	}
+	if from.Is4() != to.Is4() || from.Compare(to) > 0 {
+		return from, to, ErrBadRange
+	}
+	return
+}
+
+// IPRange 判断给出的字符串是否为有效的地址范围
+func IPRange(str string) bool {
+	_, _, err := ParseIPRange(str)
+	return err == nil
+}
+
+// RegisterIPSet 以给定的名称注册地址集合，供 IPIn 与 IPNotIn 使用，
+// 重复注册同名集合会覆盖之前的值。
+func RegisterIPSet(name string, set *IPSet) {
+	ipSetsMu.Lock()
+	defer ipSetsMu.Unlock()
+	ipSets[name] = set
+}
+
+// IPIn 判断给出的 IP 地址是否属于以 name 注册的地址集合，
+// 集合不存在时返回 false。
+func IPIn(str, name string) bool {
+	ipSetsMu.RLock()
+	set := ipSets[name]
+	ipSetsMu.RUnlock()
+	return set != nil && set.Has(str)
+}
+
+// IPNotIn 判断给出的 IP 地址是否为有效地址且不属于以 name 注册的地址集合，
+// 集合不存在时返回 false，以免拼错名称或尚未注册的黑名单放行所有地址。
+func IPNotIn(str, name string) bool {
+	if !IP(str) {
+		return false
+	}
+	ipSetsMu.RLock()
+	set := ipSets[name]
+	ipSetsMu.RUnlock()
+	return set != nil && !set.Has(str)
+}
+
+type ipTrieNode struct {
+	children [2]*ipTrieNode
+	leaf     bool
+}
+
+// IPSet 是基于前缀树的 IP 地址集合，适合在成千上万条前缀中做成员判断。
+//
+// 向集合中添加前缀不是并发安全的，构建完成后可以被多个协程同时读取。
+type IPSet struct {
+	v4 ipTrieNode
+	v6 ipTrieNode
+}
+
+// NewIPSet 创建地址集合，每一项可以是单个 IP、CIDR 或地址范围
+func NewIPSet(entries ...string) (*IPSet, error) {
+	s := new(IPSet)
+	for _, entry := range entries {
+		if err := s.AddString(entry); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// AddString 向集合中添加单个 IP、CIDR 或地址范围
+func (s *IPSet) AddString(str string) error {
+	str = strings.TrimSpace(str)
+	switch {
+	case strings.Contains(str, "/"):
+		p, err := netip.ParsePrefix(str)
+		if err != nil {
+			return err
+		}
+		s.Add(p)
+	case strings.Contains(str, "-"):
+		from, to, err := ParseIPRange(str)
+		if err != nil {
+			return err
+		}
+		s.AddRange(from, to)
+	default:
+		ip, err := netip.ParseAddr(str)
+		if err != nil {
+			return err
+		}
+		s.AddAddr(ip)
+	}
+	return nil
+}
+
+// AddAddr 向集合中添加单个 IP 地址
+func (s *IPSet) AddAddr(ip netip.Addr) {
+	ip = ip.Unmap().WithZone("")
+	s.Add(netip.PrefixFrom(ip, ip.BitLen()))
+}
+
+// Add 向集合中添加一个前缀，主机位会被忽略
+func (s *IPSet) Add(p netip.Prefix) {
+	p = unmapPrefix(p).Masked()
+	if !p.IsValid() {
+		return
+	}
+	node, bits := s.root(p.Addr())
+	for i := 0; i < p.Bits(); i++ {
+		if node.leaf {
+			// 已被更短的前缀覆盖
+			return
+		}
+		b := bitAt(bits, i)
+		if node.children[b] == nil {
+			node.children[b] = new(ipTrieNode)
+		}
+		node = node.children[b]
+	}
+	node.leaf = true
+	node.children = [2]*ipTrieNode{}
+}
+
+// AddRange 向集合中添加一个地址范围，范围会被拆分为最少数量的前缀
+func (s *IPSet) AddRange(from, to netip.Addr) {
+	from, to = from.Unmap().WithZone(""), to.Unmap().WithZone("")
+	if from.Is4() != to.Is4() || from.Compare(to) > 0 {
+		return
+	}
+	for from.IsValid() && from.Compare(to) <= 0 {
+		bits := from.BitLen()
+		for bits > 0 {
+			p := netip.PrefixFrom(from, bits-1)
+			if p.Masked().Addr() != from || lastAddr(p).Compare(to) > 0 {
+				break
+			}
+			bits--
+		}
+		p := netip.PrefixFrom(from, bits)
+		s.Add(p)
+		last := lastAddr(p)
+		if last == to {
+			break
+		}
+		from = last.Next()
+	}
+}
+
+// Contains 判断集合中是否包含给出的地址
+func (s *IPSet) Contains(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	ip = ip.Unmap().WithZone("")
+	node, bits := s.root(ip)
+	for i := 0; i < ip.BitLen(); i++ {
+		if node.leaf {
+			return true
+		}
+		node = node.children[bitAt(bits, i)]
+		if node == nil {
+			return false
+		}
+	}
+	return node.leaf
+}
+
+// Has 判断集合中是否包含给出的 IP 地址字符串，无效的地址返回 false
+func (s *IPSet) Has(str string) bool {
+	ip, err := netip.ParseAddr(str)
+	return err == nil && s.Contains(ip)
+}
+
+func (s *IPSet) root(ip netip.Addr) (*ipTrieNode, []byte) {
+	if ip.Is4() {
+		a := ip.As4()
+		return &s.v4, a[:]
+	}
+	a := ip.As16()
+	return &s.v6, a[:]
+}
+
+func bitAt(bits []byte, i int) byte {
+	return bits[i/8] >> (7 - i%8) & 1
+}
+
+// unmapPrefix 将 "::ffff:10.0.0.0/104" 这样的 IPv4 映射前缀转换为 "10.0.0.0/8"
+func unmapPrefix(p netip.Prefix) netip.Prefix {
+	addr := p.Addr().WithZone("")
+	if addr.Is4In6() && p.Bits() >= 96 {
+		return netip.PrefixFrom(addr.Unmap(), p.Bits()-96)
+	}
+	return netip.PrefixFrom(addr, p.Bits())
+}
+
+// lastAddr 返回前缀所覆盖的最后一个地址
+func lastAddr(p netip.Prefix) netip.Addr {
+	p = p.Masked()
+	a := p.Addr().As16()
+	offset := 0
+	if p.Addr().Is4() {
+		offset = 96
+	}
+	for i := p.Bits() + offset; i < 128; i++ {
+		a[i/8] |= 1 << (7 - i%8)
+	}
+	last := netip.AddrFrom16(a)
+	if p.Addr().Is4() {
+		return last.Unmap()
+	}
+	return last
+}
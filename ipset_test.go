@@ -0,0 +1,185 @@
+package is
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestCIDR(t *testing.T) {
+	tests := []struct {
+		s                    string
+		cidr, v4, v6, masked bool
+	}{
+		{"10.0.0.0/8", true, true, false, true},
+		{"10.0.0.1/8", true, true, false, false},
+		{"0.0.0.0/0", true, true, false, true},
+		{"192.168.1.1/32", true, true, false, true},
+		{"2001:db8::/32", true, false, true, true},
+		{"2001:db8::1/32", true, false, true, false},
+		{"10.0.0.0/33", false, false, false, false},
+		{"10.0.0.0", false, false, false, false},
+		{"10.0.0.0/08", false, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := CIDR(tt.s); got != tt.cidr {
+			t.Errorf("CIDR(%q) = %v, want %v", tt.s, got, tt.cidr)
+		}
+		if got := CIDRv4(tt.s); got != tt.v4 {
+			t.Errorf("CIDRv4(%q) = %v, want %v", tt.s, got, tt.v4)
+		}
+		if got := CIDRv6(tt.s); got != tt.v6 {
+			t.Errorf("CIDRv6(%q) = %v, want %v", tt.s, got, tt.v6)
+		}
+		if got := MaskedCIDR(tt.s); got != tt.masked {
+			t.Errorf("MaskedCIDR(%q) = %v, want %v", tt.s, got, tt.masked)
+		}
+	}
+}
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		s        string
+		from, to string // 为空表示应当解析失败
+	}{
+		{"10.0.0.1-10.0.0.50", "10.0.0.1", "10.0.0.50"},
+		{"10.0.0.1 - 10.0.0.1", "10.0.0.1", "10.0.0.1"},
+		{"2001:db8::1-2001:db8::ff", "2001:db8::1", "2001:db8::ff"},
+		{"10.0.0.50-10.0.0.1", "", ""},
+		{"10.0.0.1-2001:db8::1", "", ""},
+		{"10.0.0.1", "", ""},
+		{"10.0.0.1-", "", ""},
+		{"a-b", "", ""},
+	}
+	for _, tt := range tests {
+		from, to, err := ParseIPRange(tt.s)
+		if tt.from == "" {
+			if err == nil {
+				t.Errorf("ParseIPRange(%q) = %s, %s, want error", tt.s, from, to)
+			}
+			continue
+		}
+		if err != nil || from.String() != tt.from || to.String() != tt.to {
+			t.Errorf("ParseIPRange(%q) = %s, %s, %v, want %s, %s", tt.s, from, to, err, tt.from, tt.to)
+		}
+	}
+}
+
+func TestIPSet(t *testing.T) {
+	set, err := NewIPSet(
+		"10.0.0.0/8",
+		"192.168.1.10",
+		"172.16.0.5-172.16.0.20",
+		"::ffff:100.64.0.0/106",
+		"2001:db8::/32",
+		"fe80::1",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.0.0.0", true},
+		{"10.255.255.255", true},
+		{"11.0.0.0", false},
+		{"9.255.255.255", false},
+		{"192.168.1.10", true},
+		{"192.168.1.11", false},
+		{"172.16.0.4", false},
+		{"172.16.0.5", true},
+		{"172.16.0.8", true},
+		{"172.16.0.16", true},
+		{"172.16.0.20", true},
+		{"172.16.0.21", false},
+		{"100.64.0.1", true},
+		{"100.127.255.255", true},
+		{"100.128.0.0", false},
+		{"::ffff:10.1.2.3", true},
+		{"2001:db8:ffff::1", true},
+		{"2001:db9::", false},
+		{"fe80::1", true},
+		{"fe80::1%eth0", true},
+		{"fe80::2", false},
+		{"::a00:1", false},
+		{"bogus", false},
+	}
+	for _, tt := range tests {
+		if got := set.Has(tt.ip); got != tt.want {
+			t.Errorf("IPSet.Has(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+	if set.Contains(netip.Addr{}) {
+		t.Error("IPSet.Contains(zero Addr) = true, want false")
+	}
+
+	// 更短的前缀覆盖之前添加的更长前缀
+	set.Add(netip.MustParsePrefix("192.168.0.0/16"))
+	if !set.Has("192.168.200.1") {
+		t.Error("IPSet.Has(192.168.200.1) = false after adding 192.168.0.0/16")
+	}
+
+	for _, entry := range []string{"10.0.0.0/33", "10.0.0.9-10.0.0.1", "10.0.0.256"} {
+		if _, err := NewIPSet(entry); err == nil {
+			t.Errorf("NewIPSet(%q) succeeded, want error", entry)
+		}
+	}
+}
+
+func TestIPSetAddRange(t *testing.T) {
+	tests := []struct {
+		from, to string
+		inside   []string
+		outside  []string
+	}{
+		{"0.0.0.0", "255.255.255.255", []string{"0.0.0.0", "128.0.0.1", "255.255.255.255"}, []string{"::1"}},
+		{"10.0.0.255", "10.0.1.0", []string{"10.0.0.255", "10.0.1.0"}, []string{"10.0.0.254", "10.0.1.1"}},
+		{"10.0.0.1", "10.0.0.1", []string{"10.0.0.1"}, []string{"10.0.0.0", "10.0.0.2"}},
+		{"::", "::ffff", []string{"::", "::ff", "::ffff"}, []string{"::1:0"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+			[]string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}, []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffef"}},
+		{"10.0.0.9", "10.0.0.1", nil, []string{"10.0.0.1", "10.0.0.5", "10.0.0.9"}},
+	}
+	for _, tt := range tests {
+		set := new(IPSet)
+		set.AddRange(netip.MustParseAddr(tt.from), netip.MustParseAddr(tt.to))
+		for _, ip := range tt.inside {
+			if !set.Has(ip) {
+				t.Errorf("range %s-%s: Has(%q) = false, want true", tt.from, tt.to, ip)
+			}
+		}
+		for _, ip := range tt.outside {
+			if set.Has(ip) {
+				t.Errorf("range %s-%s: Has(%q) = true, want false", tt.from, tt.to, ip)
+			}
+		}
+	}
+}
+
+func TestIPInNotIn(t *testing.T) {
+	deny, err := NewIPSet("10.0.0.0/8", "2001:db8::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterIPSet("test-deny", deny)
+	tests := []struct {
+		ip, name  string
+		in, notIn bool
+	}{
+		{"10.1.2.3", "test-deny", true, false},
+		{"2001:db8::1", "test-deny", true, false},
+		{"8.8.8.8", "test-deny", false, true},
+		{"not an ip", "test-deny", false, false},
+		// 未注册的集合既不包含也不排除任何地址
+		{"10.1.2.3", "test-missing", false, false},
+		{"8.8.8.8", "test-missing", false, false},
+	}
+	for _, tt := range tests {
+		if got := IPIn(tt.ip, tt.name); got != tt.in {
+			t.Errorf("IPIn(%q, %q) = %v, want %v", tt.ip, tt.name, got, tt.in)
+		}
+		if got := IPNotIn(tt.ip, tt.name); got != tt.notIn {
+			t.Errorf("IPNotIn(%q, %q) = %v, want %v", tt.ip, tt.name, got, tt.notIn)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package is
 import (
 	"encoding/json"
 	"net"
+	"net/netip"
 	"net/url"
 	"os"
 	"reflect"
@@ -90,8 +91,10 @@ func UUID(str string) bool {
 }
 
 // ULID is the validation function for validating if the field's value is a valid ULID.
+// Input is case-insensitive and values exceeding 128 bits are rejected.
 func ULID(str string) bool {
-	return uLIDRegex.MatchString(str)
+	_, err := ParseULID(str)
+	return err == nil
 }
 
 // MD4 is the validation function for validating if the field's value is a valid MD4.
@@ -220,73 +223,40 @@ func HEXColor(str string) bool {
 	return hexColorRegex.MatchString(str)
 }
 
-// RGB is the validation function for validating if the current field's value is a valid RGB color.
+// RGB 判断给出的字符串是否为不带不透明度的 rgb() 或 rgba() 颜色值，
+// 支持逗号分隔与空格分隔两种语法，分量超出取值范围时返回 false，参见 ParseColor
 func RGB(str string) bool {
-	return rgbRegex.MatchString(str)
+	return colorFunction(str, "rgb", false)
 }
 
-// RGBA is the validation function for validating if the current field's value is a valid RGBA color.
+// RGBA 判断给出的字符串是否为带有不透明度的 rgb() 或 rgba() 颜色值，
+// CSS Color Module Level 4 中二者是等价的
 func RGBA(str string) bool {
-	return rgbaRegex.MatchString(str)
+	return colorFunction(str, "rgb", true)
 }
 
-// HSL is the validation function for validating if the current field's value is a valid HSL color.
+// HSL 判断给出的字符串是否为不带不透明度的 hsl() 或 hsla() 颜色值
 func HSL(str string) bool {
-	return hslRegex.MatchString(str)
+	return colorFunction(str, "hsl", false)
 }
 
-// HSLA is the validation function for validating if the current field's value is a valid HSLA color.
+// HSLA 判断给出的字符串是否为带有不透明度的 hsl() 或 hsla() 颜色值
 func HSLA(str string) bool {
-	return hslaRegex.MatchString(str)
-}
-
-// Color 判断给出的字符串是不是一个颜色值
-func Color(str string) bool {
-	return HEXColor(str) || HSLA(str) || HSL(str) || RGB(str) || RGBA(str)
+	return colorFunction(str, "hsl", true)
 }
 
-// Latitude is the validation function for validating if the field's value is a valid latitude coordinate.
-func Latitude[T any](t T) bool {
-	ref := reflect.ValueOf(t)
-	var v string
-	switch ref.Kind() {
-	case reflect.String:
-		v = ref.String()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v = strconv.FormatInt(ref.Int(), 10)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v = strconv.FormatUint(ref.Uint(), 10)
-	case reflect.Float32:
-		v = strconv.FormatFloat(ref.Float(), 'f', -1, 32)
-	case reflect.Float64:
-		v = strconv.FormatFloat(ref.Float(), 'f', -1, 64)
-	default:
-		//fmt.Errorf("bad ref type %T", ref.Interface())
-		return false
-	}
-	return latitudeRegex.MatchString(v)
+// colorFunction 判断颜色是否使用给出的函数表示，且所有分量都在取值范围内
+func colorFunction(str, name string, alpha bool) bool {
+	c, err := ParseColor(str)
+	return err == nil && !c.clamped && strings.TrimSuffix(c.function, "a") == name && c.hasAlpha == alpha
 }
 
-// Longitude is the validation function for validating if the field's value is a valid longitude coordinate.
-func Longitude[T any](t T) bool {
-	ref := reflect.ValueOf(t)
-	var v string
-	switch ref.Kind() {
-	case reflect.String:
-		v = ref.String()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v = strconv.FormatInt(ref.Int(), 10)
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v = strconv.FormatUint(ref.Uint(), 10)
-	case reflect.Float32:
-		v = strconv.FormatFloat(ref.Float(), 'f', -1, 32)
-	case reflect.Float64:
-		v = strconv.FormatFloat(ref.Float(), 'f', -1, 64)
-	default:
-		//fmt.Errorf("bad field type %T", ref.Interface())
-		return false
-	}
-	return longitudeRegex.MatchString(v)
+// Color 判断给出的字符串是不是一个 CSS 颜色值，参见 ParseColor。
+// 分量超出取值范围（如 "rgb(300,0,0)"、"hsl(0,150%,50%)"）时返回 false，
+// 需要按照 CSS 规范截断这类值时使用 CSSColor
+func Color(str string) bool {
+	c, err := ParseColor(str)
+	return err == nil && !c.clamped
 }
 
 // JSON is the validation function for validating if the current field's value is a valid json string.
@@ -301,9 +271,10 @@ func JSON[T any](t T) bool {
 	return false
 }
 
-func Datetime(str, layout string) bool {
-	_, err := time.Parse(layout, str)
-	return err == nil
+// Datetime 判断给出的字符串是否符合任意一个格式，格式可以是 Go 的时间布局或预置的 Datetime* 格式，
+// 没有给出格式时使用 RFC 3339，参见 ParseDatetime
+func Datetime(str string, layouts ...string) bool {
+	return CheckDatetime(str, DatetimeOptions{Layouts: layouts}) == nil
 }
 
 // Timezone is the validation function for validating if the current field's value is a valid time zone string.
@@ -324,20 +295,23 @@ func Timezone(str string) bool {
 
 // IPv4 is the validation function for validating if a value is a valid v4 IP address.
 func IPv4(str string) bool {
-	ip := net.ParseIP(str)
-	return ip != nil && ip.To4() != nil
+	ip, err := netip.ParseAddr(str)
+	return err == nil && ip.Is4()
 }
 
 // IPv6 is the validation function for validating if the field's value is a valid v6 IP address.
+// IPv4-mapped addresses such as "::ffff:192.0.2.1" are treated as v6,
+// addresses with a zone such as "fe80::1%eth0" are rejected.
 func IPv6(str string) bool {
-	ip := net.ParseIP(str)
-	return ip != nil && ip.To4() == nil
+	ip, err := netip.ParseAddr(str)
+	return err == nil && ip.Is6() && ip.Zone() == ""
 }
 
 // IP is the validation function for validating if the field's value is a valid v4 or v6 IP address.
+// Addresses with a zone such as "fe80::1%eth0" are rejected.
 func IP(str string) bool {
-	ip := net.ParseIP(str)
-	return ip != nil
+	ip, err := netip.ParseAddr(str)
+	return err == nil && ip.Zone() == ""
 }
 
 // MAC is the validation function for validating if the field's value is a valid MAC address.
@@ -475,13 +449,24 @@ func LengthBetween(val any, min, max int) bool {
 
 // Compare intX,floatX value by given op. returns `srcVal op(=,!=,<,<=,>,>=) dstVal`
 //
+// When either side is a Version, both sides are compared by version precedence,
+// the other side may be a version string. Plain strings are always compared lexically,
+// so Compare("1.10.0", "1.9.0", ">") is false: wrap one side in a Version, or use
+// CompareVersion and VersionBetween to compare version strings.
+//
 // Usage:
 //
 //	compare(2, 3, ">") // false
 //	compare(2, 1.3, ">") // true
 //	compare(2.2, 1.3, ">") // true
 //	compare(2.1, 2, ">") // true
+//	compare(Version{Major: 1, Minor: 10}, "1.9.0", ">") // true
 func Compare(srcVal, dstVal any, op string) bool {
+	if isVersion(srcVal) || isVersion(dstVal) {
+		// Version 包含切片，不能使用下方的 == 比较
+		return CompareVersion(srcVal, dstVal, op)
+	}
+
 	srv := reflect.ValueOf(srcVal)
 
 	switch srv.Kind() {
@@ -575,7 +560,7 @@ func NotEqual(a, b any) bool {
 }
 
 func Between(val, min, max any) bool {
-	if !Compare(min, max, ">") {
+	if Compare(min, max, ">") {
 		panic(ErrBadRange)
 	}
 
@@ -583,7 +568,7 @@ func Between(val, min, max any) bool {
 }
 
 func NotBetween(val, min, max any) bool {
-	if !Compare(min, max, ">") {
+	if Compare(min, max, ">") {
 		panic(ErrBadRange)
 	}
 
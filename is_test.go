@@ -0,0 +1,33 @@
+package is
+
+import "testing"
+
+func TestCompareStrings(t *testing.T) {
+	tests := []struct {
+		a, b, op string
+		want     bool
+	}{
+		{"a", "b", "<", true},
+		{"a", "b", "<=", true},
+		{"a", "a", "<=", true},
+		{"b", "a", ">", true},
+		{"b", "b", ">=", true},
+		{"a", "a", "=", true},
+		{"a", "b", "=", false},
+		{"a", "b", "!=", true},
+		{"a", "a", "!=", false},
+		{"", "a", "!=", true},
+		{"a", "b", "~", false},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b, tt.op); got != tt.want {
+			t.Errorf("Compare(%q, %q, %q) = %v, want %v", tt.a, tt.b, tt.op, got, tt.want)
+		}
+	}
+	if !NotEqual("foo", "bar") {
+		t.Error(`NotEqual("foo", "bar") = false, want true`)
+	}
+	if NotEqual("foo", "foo") {
+		t.Error(`NotEqual("foo", "foo") = true, want false`)
+	}
+}
@@ -0,0 +1,289 @@
+package is
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+)
+
+var (
+	ErrJWTSignature   = errors.New("jwt: signature is invalid")
+	ErrJWTKeyNotFound = errors.New("jwt: no matching key")
+	ErrBadJWK         = errors.New("jwk: invalid key")
+)
+
+// JWK 是 JSON Web Key 中的一个公钥或对称密钥
+type JWK struct {
+	// KeyID 对应 "kid"
+	KeyID string
+	// Algorithm 对应 "alg"，为空表示不限制
+	Algorithm string
+	// Key 为 []byte、*rsa.PublicKey、*ecdsa.PublicKey 或 ed25519.PublicKey
+	Key any
+}
+
+// JWKSet 是 JSON Web Key Set（RFC 7517），用于按照 "kid" 选择验证签名的密钥
+type JWKSet struct {
+	Keys []JWK
+}
+
+type rawJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS 解析 JSON 格式的 JWK Set，支持 RSA、EC（P-256、P-384、P-521）、
+// OKP（Ed25519）与 oct 类型的密钥，其它类型以及 "use" 不为 "sig" 的密钥会被忽略。
+func ParseJWKS(data []byte) (*JWKSet, error) {
+	var doc struct {
+		Keys []rawJWK `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrBadJWK
+	}
+	set := &JWKSet{}
+	for _, raw := range doc.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, err
+		}
+		if key != nil {
+			set.Keys = append(set.Keys, JWK{KeyID: raw.Kid, Algorithm: raw.Alg, Key: key})
+		}
+	}
+	return set, nil
+}
+
+// ReadJWKS 从 r 中读取并解析 JWK Set
+func ReadJWKS(r io.Reader) (*JWKSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// LoadJWKS 从文件中读取并解析 JWK Set
+func LoadJWKS(path string) (*JWKSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+func (raw rawJWK) publicKey() (any, error) {
+	switch raw.Kty {
+	case "RSA":
+		n, err1 := decodeJWKBigInt(raw.N)
+		e, err2 := decodeJWKBigInt(raw.E)
+		if err1 != nil || err2 != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, ErrBadJWK
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch raw.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, nil
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		x, err1 := base64.RawURLEncoding.DecodeString(raw.X)
+		y, err2 := base64.RawURLEncoding.DecodeString(raw.Y)
+		if err1 != nil || err2 != nil || len(x) != size || len(y) != size {
+			return nil, ErrBadJWK
+		}
+		// 借助 crypto/ecdh 检查坐标是否位于曲线上
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, ErrBadJWK
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if raw.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(raw.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, ErrBadJWK
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(raw.K)
+		if err != nil || len(k) == 0 {
+			return nil, ErrBadJWK
+		}
+		return k, nil
+	}
+	return nil, nil
+}
+
+func decodeJWKBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, ErrBadJWK
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// VerifyJWT 解码 JWT、验证签名，并按照给出的策略校验算法与注册声明。
+//
+// key 可以是 HS256/384/512 使用的 []byte 密钥，RS256/384/512 与 PS256/384/512 使用的
+// *rsa.PublicKey，ES256/384/512 使用的 *ecdsa.PublicKey，EdDSA 使用的 ed25519.PublicKey，
+// 或者 *JWKSet。使用 *JWKSet 时按照头部中的 "kid" 选择密钥，没有 "kid" 时依次尝试
+// 所有与算法匹配的密钥。密钥类型必须与头部声明的算法相符，以防止算法混淆攻击。
+func VerifyJWT(s string, key any, opts JWTOptions) (*ParsedJWT, error) {
+	t, err := ParseJWT(s)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.Validate(opts); err != nil {
+		return nil, err
+	}
+	if err = t.Verify(key); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Verify 使用给出的密钥验证签名，key 的取值同 VerifyJWT
+func (t *ParsedJWT) Verify(key any) error {
+	set, ok := key.(*JWKSet)
+	if !ok {
+		return verifyJWS(t.Algorithm(), t.signingInput, t.Signature, key)
+	}
+	alg, kid := t.Algorithm(), t.KeyID()
+	found := false
+	for _, k := range set.Keys {
+		if (kid != "" && k.KeyID != kid) || (k.Algorithm != "" && k.Algorithm != alg) {
+			continue
+		}
+		err := verifyJWS(alg, t.signingInput, t.Signature, k.Key)
+		switch err {
+		case nil:
+			return nil
+		case ErrJWTAlgorithm:
+			return err
+		case ErrJWTSignature:
+			found = true
+		}
+	}
+	if found {
+		return ErrJWTSignature
+	}
+	return ErrJWTKeyNotFound
+}
+
+// verifyJWS 验证 JWS 签名，密钥类型与算法不匹配时返回 ErrJWTKeyNotFound
+func verifyJWS(alg, input string, sig []byte, key any) error {
+	var hash crypto.Hash
+	switch alg {
+	case "HS256", "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "HS384", "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "HS512", "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+		k, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return ErrJWTKeyNotFound
+		}
+		if !ed25519.Verify(k, []byte(input), sig) {
+			return ErrJWTSignature
+		}
+		return nil
+	default:
+		return ErrJWTAlgorithm
+	}
+	switch alg[:2] {
+	case "HS":
+		k, ok := key.([]byte)
+		if !ok {
+			return ErrJWTKeyNotFound
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(input))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return ErrJWTSignature
+		}
+		return nil
+	}
+	h := hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+	switch alg[:2] {
+	case "RS", "PS":
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrJWTKeyNotFound
+		}
+		var err error
+		if alg[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(k, hash, digest, sig)
+		} else {
+			err = rsa.VerifyPSS(k, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		if err != nil {
+			return ErrJWTSignature
+		}
+		return nil
+	default:
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok || k.Curve.Params().BitSize != ecdsaCurveBits(hash) {
+			return ErrJWTKeyNotFound
+		}
+		// JWS 中的 ECDSA 签名是定长的 R || S
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrJWTSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return ErrJWTSignature
+		}
+		return nil
+	}
+}
+
+// ecdsaCurveBits 返回 ES256、ES384、ES512 对应曲线的位数
+func ecdsaCurveBits(hash crypto.Hash) int {
+	switch hash {
+	case crypto.SHA256:
+		return 256
+	case crypto.SHA384:
+		return 384
+	}
+	return 521
+}
@@ -0,0 +1,219 @@
+package is
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+var b64url = base64.RawURLEncoding.EncodeToString
+
+// signJWT 使用 sign 对头部与载荷签名，生成紧凑格式的 JWT
+func signJWT(t *testing.T, header map[string]any, sign func(input []byte) []byte) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := b64url(h) + "." + b64url([]byte(`{"sub":"1234567890"}`))
+	return input + "." + b64url(sign([]byte(input)))
+}
+
+func digest(hash crypto.Hash, input []byte) []byte {
+	h := hash.New()
+	h.Write(input)
+	return h.Sum(nil)
+}
+
+func hmacSigner(hash crypto.Hash, key []byte) func([]byte) []byte {
+	return func(input []byte) []byte {
+		mac := hmac.New(hash.New, key)
+		mac.Write(input)
+		return mac.Sum(nil)
+	}
+}
+
+func rsaSigner(t *testing.T, hash crypto.Hash, key *rsa.PrivateKey, pss bool) func([]byte) []byte {
+	return func(input []byte) []byte {
+		var sig []byte
+		var err error
+		if pss {
+			sig, err = rsa.SignPSS(rand.Reader, key, hash, digest(hash, input), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, key, hash, digest(hash, input))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+}
+
+func ecdsaSigner(t *testing.T, hash crypto.Hash, key *ecdsa.PrivateKey) func([]byte) []byte {
+	return func(input []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest(hash, input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig
+	}
+}
+
+func TestVerifyJWS(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	p521, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	rsaDER, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	otherSecret := []byte("another secret")
+
+	tests := []struct {
+		name string
+		alg  string
+		sign func([]byte) []byte
+		key  any
+		want error
+	}{
+		{"HS256", "HS256", hmacSigner(crypto.SHA256, secret), secret, nil},
+		{"HS384", "HS384", hmacSigner(crypto.SHA384, secret), secret, nil},
+		{"HS512", "HS512", hmacSigner(crypto.SHA512, secret), secret, nil},
+		{"RS256", "RS256", rsaSigner(t, crypto.SHA256, rsaKey, false), &rsaKey.PublicKey, nil},
+		{"RS512", "RS512", rsaSigner(t, crypto.SHA512, rsaKey, false), &rsaKey.PublicKey, nil},
+		{"PS256", "PS256", rsaSigner(t, crypto.SHA256, rsaKey, true), &rsaKey.PublicKey, nil},
+		{"PS384", "PS384", rsaSigner(t, crypto.SHA384, rsaKey, true), &rsaKey.PublicKey, nil},
+		{"ES256", "ES256", ecdsaSigner(t, crypto.SHA256, p256), &p256.PublicKey, nil},
+		{"ES384", "ES384", ecdsaSigner(t, crypto.SHA384, p384), &p384.PublicKey, nil},
+		{"ES512", "ES512", ecdsaSigner(t, crypto.SHA512, p521), &p521.PublicKey, nil},
+		{"EdDSA", "EdDSA", func(input []byte) []byte { return ed25519.Sign(edPriv, input) }, edPub, nil},
+
+		{"wrong secret", "HS256", hmacSigner(crypto.SHA256, otherSecret), secret, ErrJWTSignature},
+		{"PKCS1 signature as PSS", "PS256", rsaSigner(t, crypto.SHA256, rsaKey, false), &rsaKey.PublicKey, ErrJWTSignature},
+		{"truncated ECDSA signature", "ES256", func(input []byte) []byte { return ecdsaSigner(t, crypto.SHA256, p256)(input)[:63] }, &p256.PublicKey, ErrJWTSignature},
+		{"ASN.1 ECDSA signature", "ES256", func(input []byte) []byte {
+			sig, _ := ecdsa.SignASN1(rand.Reader, p256, digest(crypto.SHA256, input))
+			return sig
+		}, &p256.PublicKey, ErrJWTSignature},
+
+		// 算法混淆：以 RSA 公钥作为 HMAC 密钥签名，或者头部算法与密钥类型不符
+		{"HS256 signed with RSA public key", "HS256", hmacSigner(crypto.SHA256, rsaDER), &rsaKey.PublicKey, ErrJWTKeyNotFound},
+		{"RS256 header with HMAC key", "RS256", hmacSigner(crypto.SHA256, secret), secret, ErrJWTKeyNotFound},
+		{"ES256 header with P-384 key", "ES256", ecdsaSigner(t, crypto.SHA256, p384), &p384.PublicKey, ErrJWTKeyNotFound},
+		{"EdDSA header with ECDSA key", "EdDSA", ecdsaSigner(t, crypto.SHA256, p256), &p256.PublicKey, ErrJWTKeyNotFound},
+		{"none", "none", func([]byte) []byte { return nil }, secret, ErrJWTAlgorithm},
+		{"unknown algorithm", "XS256", hmacSigner(crypto.SHA256, secret), secret, ErrJWTAlgorithm},
+	}
+	for _, tt := range tests {
+		token := signJWT(t, map[string]any{"alg": tt.alg, "typ": "JWT"}, tt.sign)
+		if _, err := VerifyJWT(token, tt.key, JWTOptions{}); err != tt.want {
+			t.Errorf("%s: VerifyJWT = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// 篡改载荷后签名失效
+	token := signJWT(t, map[string]any{"alg": "HS256"}, hmacSigner(crypto.SHA256, secret))
+	parsed, err := ParseJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed.signingInput += "x"
+	if err = parsed.Verify(secret); err != ErrJWTSignature {
+		t.Errorf("Verify tampered token = %v, want %v", err, ErrJWTSignature)
+	}
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherEC, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	ecSize := 32
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rsa-1", "alg": "RS256", "n": b64url(rsaKey.N.Bytes()), "e": b64url(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64url(ecKey.X.FillBytes(make([]byte, ecSize))), "y": b64url(ecKey.Y.FillBytes(make([]byte, ecSize)))},
+		{"kty": "EC", "kid": "ec-2", "crv": "P-256", "x": b64url(otherEC.X.FillBytes(make([]byte, ecSize))), "y": b64url(otherEC.Y.FillBytes(make([]byte, ecSize)))},
+		{"kty": "OKP", "kid": "ed-1", "crv": "Ed25519", "x": b64url(edPub)},
+		{"kty": "oct", "kid": "hs-1", "alg": "HS256", "k": b64url(secret)},
+		{"kty": "oct", "kid": "enc-1", "use": "enc", "k": b64url([]byte("encryption key"))},
+		{"kty": "OKP", "kid": "x-1", "crv": "X25519", "x": b64url(edPub)},
+	}})
+	set, err := ParseJWKS(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Keys) != 5 {
+		t.Fatalf("ParseJWKS returned %d keys, want 5", len(set.Keys))
+	}
+
+	tests := []struct {
+		name   string
+		header map[string]any
+		sign   func([]byte) []byte
+		want   error
+	}{
+		{"RSA by kid", map[string]any{"alg": "RS256", "kid": "rsa-1"}, rsaSigner(t, crypto.SHA256, rsaKey, false), nil},
+		{"EC by kid", map[string]any{"alg": "ES256", "kid": "ec-1"}, ecdsaSigner(t, crypto.SHA256, ecKey), nil},
+		{"second EC by kid", map[string]any{"alg": "ES256", "kid": "ec-2"}, ecdsaSigner(t, crypto.SHA256, otherEC), nil},
+		{"EdDSA by kid", map[string]any{"alg": "EdDSA", "kid": "ed-1"}, func(input []byte) []byte { return ed25519.Sign(edPriv, input) }, nil},
+		{"HMAC by kid", map[string]any{"alg": "HS256", "kid": "hs-1"}, hmacSigner(crypto.SHA256, secret), nil},
+		{"EC without kid", map[string]any{"alg": "ES256"}, ecdsaSigner(t, crypto.SHA256, otherEC), nil},
+
+		{"kid points to another EC key", map[string]any{"alg": "ES256", "kid": "ec-1"}, ecdsaSigner(t, crypto.SHA256, otherEC), ErrJWTSignature},
+		{"unknown kid", map[string]any{"alg": "ES256", "kid": "ec-9"}, ecdsaSigner(t, crypto.SHA256, ecKey), ErrJWTKeyNotFound},
+		{"encryption key is ignored", map[string]any{"alg": "HS256", "kid": "enc-1"}, hmacSigner(crypto.SHA256, []byte("encryption key")), ErrJWTKeyNotFound},
+		{"alg differs from key alg", map[string]any{"alg": "RS512", "kid": "rsa-1"}, rsaSigner(t, crypto.SHA512, rsaKey, false), ErrJWTKeyNotFound},
+		{"HMAC with RSA modulus", map[string]any{"alg": "HS256", "kid": "rsa-1"}, hmacSigner(crypto.SHA256, rsaKey.N.Bytes()), ErrJWTKeyNotFound},
+		{"HMAC key used for RS256", map[string]any{"alg": "RS256", "kid": "hs-1"}, hmacSigner(crypto.SHA256, secret), ErrJWTKeyNotFound},
+		{"no key matches without kid", map[string]any{"alg": "PS256"}, rsaSigner(t, crypto.SHA256, rsaKey, true), ErrJWTKeyNotFound},
+	}
+	for _, tt := range tests {
+		token := signJWT(t, tt.header, tt.sign)
+		if _, err := VerifyJWT(token, set, JWTOptions{}); err != tt.want {
+			t.Errorf("%s: VerifyJWT = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestParseJWKSInvalid(t *testing.T) {
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	x := b64url(p256.X.FillBytes(make([]byte, 32)))
+	y := b64url(p256.Y.FillBytes(make([]byte, 32)))
+	offCurve := b64url(new(big.Int).Add(p256.Y, big.NewInt(1)).FillBytes(make([]byte, 32)))
+	tests := []string{
+		`{"keys":[{"kty":"RSA","n":"AQAB","e":"AQ"}]}`,
+		`{"keys":[{"kty":"RSA","n":"","e":"AQAB"}]}`,
+		`{"keys":[{"kty":"EC","crv":"P-256","x":"` + x + `","y":"` + offCurve + `"}]}`,
+		`{"keys":[{"kty":"EC","crv":"P-256","x":"` + x + `","y":"` + y[:10] + `"}]}`,
+		`{"keys":[{"kty":"OKP","crv":"Ed25519","x":"AAAA"}]}`,
+		`{"keys":[{"kty":"oct","k":""}]}`,
+		`{"keys":{}}`,
+		`not json`,
+	}
+	for _, data := range tests {
+		if _, err := ParseJWKS([]byte(data)); err != ErrBadJWK {
+			t.Errorf("ParseJWKS(%s) = %v, want %v", data, err, ErrBadJWK)
+		}
+	}
+}
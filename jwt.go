@@ -0,0 +1,195 @@
+package is
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadJWT            = errors.New("jwt: malformed token")
+	ErrJWTAlgorithm      = errors.New("jwt: algorithm not allowed")
+	ErrJWTExpired        = errors.New("jwt: token is expired")
+	ErrJWTNotYetValid    = errors.New("jwt: token is not valid yet")
+	ErrJWTIssuedInFuture = errors.New("jwt: token used before issued")
+	ErrJWTIssuer         = errors.New("jwt: issuer mismatch")
+	ErrJWTAudience       = errors.New("jwt: audience mismatch")
+	ErrJWTMissingClaim   = errors.New("jwt: required claim missing")
+)
+
+// ParsedJWT 是解码后的 JWT，签名未经验证
+type ParsedJWT struct {
+	// Header JOSE 头部，数值类型为 json.Number
+	Header map[string]any
+	// Claims 载荷中的声明，数值类型为 json.Number
+	Claims map[string]any
+	// Signature 解码后的签名
+	Signature []byte
+
+	// signingInput 是参与签名的 "header.payload" 部分
+	signingInput string
+}
+
+// JWTOptions 定义 CheckJWT 对头部与注册声明的校验策略
+type JWTOptions struct {
+	// Algorithms 允许的签名算法，为空时允许除 "none" 以外的任意算法，
+	// "none" 即使出现在列表中也会被拒绝
+	Algorithms []string
+	// Issuer 要求 "iss" 声明与之相等，为空时不检查
+	Issuer string
+	// Audience 要求 "aud" 声明包含该值，为空时不检查
+	Audience string
+	// RequireExp 要求必须包含 "exp" 声明
+	RequireExp bool
+	// Leeway 校验 "exp"、"nbf"、"iat" 时允许的时钟偏差
+	Leeway time.Duration
+	// Now 返回当前时间，为空时使用 time.Now
+	Now func() time.Time
+}
+
+// ParseJWT 解码 JWT 的结构，要求头部与载荷均为 base64url 编码的 JSON 对象，
+// 且头部包含字符串类型的 "alg"。该函数不验证签名。
+func ParseJWT(s string) (*ParsedJWT, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, ErrBadJWT
+	}
+	t := &ParsedJWT{signingInput: parts[0] + "." + parts[1]}
+	if err := decodeJWTSegment(parts[0], &t.Header); err != nil {
+		return nil, err
+	}
+	if err := decodeJWTSegment(parts[1], &t.Claims); err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrBadJWT
+	}
+	t.Signature = sig
+	if _, ok := t.Header["alg"].(string); !ok {
+		return nil, ErrBadJWT
+	}
+	return t, nil
+}
+
+func decodeJWTSegment(seg string, v *map[string]any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return ErrBadJWT
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(v); err != nil || *v == nil || dec.More() {
+		return ErrBadJWT
+	}
+	return nil
+}
+
+// Algorithm 返回头部中的 "alg"
+func (t *ParsedJWT) Algorithm() string {
+	alg, _ := t.Header["alg"].(string)
+	return alg
+}
+
+// KeyID 返回头部中的 "kid"，不存在时返回空字符串
+func (t *ParsedJWT) KeyID() string {
+	kid, _ := t.Header["kid"].(string)
+	return kid
+}
+
+// CheckJWT 解码 JWT 并按照给出的策略校验算法与注册声明，返回具体的失败原因，
+// 该函数不验证签名。
+func CheckJWT(s string, opts JWTOptions) error {
+	t, err := ParseJWT(s)
+	if err != nil {
+		return err
+	}
+	return t.Validate(opts)
+}
+
+// Validate 按照给出的策略校验算法与注册声明
+func (t *ParsedJWT) Validate(opts JWTOptions) error {
+	alg := t.Algorithm()
+	if strings.EqualFold(alg, "none") {
+		return ErrJWTAlgorithm
+	}
+	if len(opts.Algorithms) > 0 && !slices.Contains(opts.Algorithms, alg) {
+		return ErrJWTAlgorithm
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	current := now()
+	exp, ok, err := t.numericDate("exp")
+	if err != nil {
+		return err
+	}
+	if !ok && opts.RequireExp {
+		return ErrJWTMissingClaim
+	}
+	if ok && !current.Before(exp.Add(opts.Leeway)) {
+		return ErrJWTExpired
+	}
+	if nbf, ok, err := t.numericDate("nbf"); err != nil {
+		return err
+	} else if ok && current.Add(opts.Leeway).Before(nbf) {
+		return ErrJWTNotYetValid
+	}
+	if iat, ok, err := t.numericDate("iat"); err != nil {
+		return err
+	} else if ok && current.Add(opts.Leeway).Before(iat) {
+		return ErrJWTIssuedInFuture
+	}
+	if opts.Issuer != "" {
+		if iss, _ := t.Claims["iss"].(string); iss != opts.Issuer {
+			return ErrJWTIssuer
+		}
+	}
+	if opts.Audience != "" && !t.hasAudience(opts.Audience) {
+		return ErrJWTAudience
+	}
+	return nil
+}
+
+// maxNumericDate 是 NumericDate 允许的最大绝对值，即 float64 能精确表示的最大整数，
+// 超出该范围的值无法安全地转换为 int64 秒数
+const maxNumericDate = 1 << 53
+
+// numericDate 读取 NumericDate 类型的声明，即自 Unix 纪元起的秒数，允许小数
+func (t *ParsedJWT) numericDate(name string) (time.Time, bool, error) {
+	v, ok := t.Claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, isNumber := v.(json.Number)
+	if !isNumber {
+		return time.Time{}, false, ErrBadJWT
+	}
+	f, err := n.Float64()
+	if err != nil || math.Abs(f) > maxNumericDate {
+		return time.Time{}, false, ErrBadJWT
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true, nil
+}
+
+// hasAudience 判断 "aud" 声明是否包含给出的值，"aud" 可以是字符串或字符串数组
+func (t *ParsedJWT) hasAudience(aud string) bool {
+	switch v := t.Claims["aud"].(type) {
+	case string:
+		return v == aud
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == aud {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package is
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// unsignedJWT 拼接头部与载荷，签名部分为给出的字节
+func unsignedJWT(header, claims string) string {
+	return b64url([]byte(header)) + "." + b64url([]byte(claims)) + "." + b64url([]byte("sig"))
+}
+
+func TestParseJWT(t *testing.T) {
+	valid := unsignedJWT(`{"alg":"HS256","kid":"k1"}`, `{"sub":"1","n":1.5}`)
+	tok, err := ParseJWT(valid)
+	if err != nil {
+		t.Fatalf("ParseJWT() = %v", err)
+	}
+	if tok.Algorithm() != "HS256" || tok.KeyID() != "k1" || string(tok.Signature) != "sig" {
+		t.Errorf("ParseJWT() = %+v", tok)
+	}
+	if tok.Claims["sub"] != "1" || tok.Claims["n"] != json.Number("1.5") {
+		t.Errorf("ParseJWT().Claims = %v", tok.Claims)
+	}
+
+	invalid := []string{
+		"",
+		"a.b",
+		"a.b.c.d",
+		unsignedJWT(`{"alg":"HS256"}`, `{}`) + "=",
+		unsignedJWT(`{"typ":"JWT"}`, `{}`),
+		unsignedJWT(`{"alg":256}`, `{}`),
+		unsignedJWT(`{"alg":"HS256"}`, `[]`),
+		unsignedJWT(`{"alg":"HS256"}`, `null`),
+		unsignedJWT(`{"alg":"HS256"}`, `{}{}`),
+		unsignedJWT(`{"alg":"HS256"}`, `{"sub":`),
+		unsignedJWT(`"HS256"`, `{}`),
+		"eyJhbGciOiJIUzI1NiJ9.e30=.c2ln",
+		"eyJhbGciOiJIUzI1NiJ9.e30.c2ln+",
+	}
+	for _, s := range invalid {
+		if _, err := ParseJWT(s); !errors.Is(err, ErrBadJWT) {
+			t.Errorf("ParseJWT(%q) = %v, want ErrBadJWT", s, err)
+		}
+	}
+}
+
+func TestCheckJWT(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	opts := func(o JWTOptions) JWTOptions {
+		o.Now = func() time.Time { return now }
+		return o
+	}
+	hs := func(claims string) string { return unsignedJWT(`{"alg":"HS256"}`, claims) }
+	tests := []struct {
+		s    string
+		opts JWTOptions
+		want error
+	}{
+		{hs(`{}`), opts(JWTOptions{}), nil},
+
+		// 算法
+		{unsignedJWT(`{"alg":"none"}`, `{}`), opts(JWTOptions{}), ErrJWTAlgorithm},
+		{unsignedJWT(`{"alg":"NONE"}`, `{}`), opts(JWTOptions{}), ErrJWTAlgorithm},
+		{unsignedJWT(`{"alg":"none"}`, `{}`), opts(JWTOptions{Algorithms: []string{"none"}}), ErrJWTAlgorithm},
+		{hs(`{}`), opts(JWTOptions{Algorithms: []string{"RS256", "ES256"}}), ErrJWTAlgorithm},
+		{hs(`{}`), opts(JWTOptions{Algorithms: []string{"RS256", "HS256"}}), nil},
+
+		// exp 及其时钟偏差
+		{hs(`{"exp":1700000001}`), opts(JWTOptions{}), nil},
+		{hs(`{"exp":1700000000}`), opts(JWTOptions{}), ErrJWTExpired},
+		{hs(`{"exp":1699999990}`), opts(JWTOptions{Leeway: 10 * time.Second}), ErrJWTExpired},
+		{hs(`{"exp":1699999991}`), opts(JWTOptions{Leeway: 10 * time.Second}), nil},
+		{hs(`{"exp":1700000000.5}`), opts(JWTOptions{}), nil},
+		{hs(`{}`), opts(JWTOptions{RequireExp: true}), ErrJWTMissingClaim},
+		{hs(`{"exp":"1700000001"}`), opts(JWTOptions{}), ErrBadJWT},
+
+		// nbf 及其时钟偏差
+		{hs(`{"nbf":1700000000}`), opts(JWTOptions{}), nil},
+		{hs(`{"nbf":1700000001}`), opts(JWTOptions{}), ErrJWTNotYetValid},
+		{hs(`{"nbf":1700000010}`), opts(JWTOptions{Leeway: 10 * time.Second}), nil},
+		{hs(`{"nbf":1700000011}`), opts(JWTOptions{Leeway: 10 * time.Second}), ErrJWTNotYetValid},
+
+		// iat
+		{hs(`{"iat":1700000000}`), opts(JWTOptions{}), nil},
+		{hs(`{"iat":1700000060}`), opts(JWTOptions{}), ErrJWTIssuedInFuture},
+		{hs(`{"iat":1700000060}`), opts(JWTOptions{Leeway: time.Minute}), nil},
+
+		// 超出范围的 NumericDate
+		{hs(`{"nbf":1e300}`), opts(JWTOptions{}), ErrBadJWT},
+		{hs(`{"exp":-1e300}`), opts(JWTOptions{}), ErrBadJWT},
+		{hs(`{"iat":1e400}`), opts(JWTOptions{}), ErrBadJWT},
+		{hs(`{"exp":9007199254740994}`), opts(JWTOptions{}), ErrBadJWT},
+		{hs(`{"exp":9007199254740992}`), opts(JWTOptions{}), nil},
+
+		// iss
+		{hs(`{"iss":"https://issuer.example"}`), opts(JWTOptions{Issuer: "https://issuer.example"}), nil},
+		{hs(`{"iss":"https://other.example"}`), opts(JWTOptions{Issuer: "https://issuer.example"}), ErrJWTIssuer},
+		{hs(`{}`), opts(JWTOptions{Issuer: "https://issuer.example"}), ErrJWTIssuer},
+
+		// aud 可以是字符串或数组
+		{hs(`{"aud":"api"}`), opts(JWTOptions{Audience: "api"}), nil},
+		{hs(`{"aud":"web"}`), opts(JWTOptions{Audience: "api"}), ErrJWTAudience},
+		{hs(`{"aud":["web","api"]}`), opts(JWTOptions{Audience: "api"}), nil},
+		{hs(`{"aud":["web",1]}`), opts(JWTOptions{Audience: "api"}), ErrJWTAudience},
+		{hs(`{"aud":[]}`), opts(JWTOptions{Audience: "api"}), ErrJWTAudience},
+		{hs(`{}`), opts(JWTOptions{Audience: "api"}), ErrJWTAudience},
+		{hs(`{"aud":"web"}`), opts(JWTOptions{}), nil},
+
+		{"a.b", opts(JWTOptions{}), ErrBadJWT},
+	}
+	for _, tt := range tests {
+		if err := CheckJWT(tt.s, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckJWT(%q) = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
+
+func TestValidateJWT(t *testing.T) {
+	tok, err := ParseJWT(unsignedJWT(`{"alg":"ES256"}`, `{"exp":100,"aud":["a","b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(sec int64) func() time.Time { return func() time.Time { return time.Unix(sec, 0) } }
+	if err := tok.Validate(JWTOptions{Audience: "b", Now: at(99)}); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := tok.Validate(JWTOptions{Audience: "b", Now: at(100)}); !errors.Is(err, ErrJWTExpired) {
+		t.Errorf("Validate() = %v, want ErrJWTExpired", err)
+	}
+	if err := tok.Validate(JWTOptions{Audience: "c", Now: at(99)}); !errors.Is(err, ErrJWTAudience) {
+		t.Errorf("Validate() = %v, want ErrJWTAudience", err)
+	}
+}
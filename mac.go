@@ -0,0 +1,114 @@
+package is
+
+import (
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+)
+
+var ErrBadMAC = errors.New("bad mac address")
+
+// MACFormat 表示 MAC 地址的书写格式
+type MACFormat int
+
+const (
+	MACColon  MACFormat = iota // 00:00:5e:00:53:01
+	MACHyphen                  // 00-00-5e-00-53-01
+	MACDot                     // 0000.5e00.5301，思科设备常用
+	MACBare                    // 00005e005301
+)
+
+// ParseMAC 解析 MAC 地址，除 net.ParseMAC 支持的格式外，
+// 还接受不带分隔符的 EUI-48 和 EUI-64 十六进制字符串。
+func ParseMAC(str string) (net.HardwareAddr, error) {
+	if len(str) == 12 || len(str) == 16 {
+		if b, err := hex.DecodeString(str); err == nil {
+			return b, nil
+		}
+	}
+	mac, err := net.ParseMAC(str)
+	if err != nil {
+		return nil, ErrBadMAC
+	}
+	return mac, nil
+}
+
+// MAC48 判断给出的字符串是否为 EUI-48 格式的 MAC 地址
+func MAC48(str string) bool {
+	mac, err := ParseMAC(str)
+	return err == nil && len(mac) == 6
+}
+
+// MAC64 判断给出的字符串是否为 EUI-64 格式的 MAC 地址
+func MAC64(str string) bool {
+	mac, err := ParseMAC(str)
+	return err == nil && len(mac) == 8
+}
+
+// parseEUI 解析 EUI-48 或 EUI-64 格式的 MAC 地址，
+// net.ParseMAC 接受的 20 字节 IPoIB 地址不具有 I/G 与 U/L 位，因此不予接受。
+func parseEUI(str string) (net.HardwareAddr, bool) {
+	mac, err := ParseMAC(str)
+	return mac, err == nil && (len(mac) == 6 || len(mac) == 8)
+}
+
+// UnicastMAC 判断给出的字符串是否为单播 MAC 地址（I/G 位为 0）
+func UnicastMAC(str string) bool {
+	mac, ok := parseEUI(str)
+	return ok && mac[0]&0x01 == 0
+}
+
+// MulticastMAC 判断给出的字符串是否为组播 MAC 地址（I/G 位为 1），
+// 广播地址 ff:ff:ff:ff:ff:ff 也属于组播地址。
+func MulticastMAC(str string) bool {
+	mac, ok := parseEUI(str)
+	return ok && mac[0]&0x01 == 1
+}
+
+// LocalMAC 判断给出的字符串是否为本地管理的 MAC 地址（U/L 位为 1），
+// 虚拟机、容器以及随机化的 MAC 地址通常属于此类。
+func LocalMAC(str string) bool {
+	mac, ok := parseEUI(str)
+	return ok && mac[0]&0x02 != 0
+}
+
+// UniversalMAC 判断给出的字符串是否为全球唯一的 MAC 地址（U/L 位为 0）
+func UniversalMAC(str string) bool {
+	mac, ok := parseEUI(str)
+	return ok && mac[0]&0x02 == 0
+}
+
+// NormalizeMAC 将 MAC 地址转换为指定格式的小写字符串
+func NormalizeMAC(str string, format MACFormat) (string, error) {
+	mac, err := ParseMAC(str)
+	if err != nil {
+		return "", err
+	}
+	return FormatMAC(mac, format), nil
+}
+
+// FormatMAC 将 MAC 地址格式化为指定格式的小写字符串
+func FormatMAC(mac net.HardwareAddr, format MACFormat) string {
+	s := hex.EncodeToString(mac)
+	var sep string
+	var size int
+	switch format {
+	case MACHyphen:
+		sep, size = "-", 2
+	case MACDot:
+		sep, size = ".", 4
+	case MACBare:
+		return s
+	default:
+		sep, size = ":", 2
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i += size {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s[i : i+size])
+	}
+	return b.String()
+}
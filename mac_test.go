@@ -0,0 +1,34 @@
+package is
+
+import "testing"
+
+func TestMACBits(t *testing.T) {
+	const ipoib = "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01"
+	tests := []struct {
+		mac                                  string
+		unicast, multicast, local, universal bool
+	}{
+		{"00:00:5e:00:53:01", true, false, false, true},
+		{"01:00:5e:00:53:01", false, true, false, true},
+		{"ff:ff:ff:ff:ff:ff", false, true, true, false},
+		{"02:42:ac:11:00:02", true, false, true, false},
+		{"02:00:5e:10:00:00:00:01", true, false, true, false},
+		{"00005e005301", true, false, false, true},
+		{ipoib, false, false, false, false},
+		{"not a mac", false, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := UnicastMAC(tt.mac); got != tt.unicast {
+			t.Errorf("UnicastMAC(%q) = %v, want %v", tt.mac, got, tt.unicast)
+		}
+		if got := MulticastMAC(tt.mac); got != tt.multicast {
+			t.Errorf("MulticastMAC(%q) = %v, want %v", tt.mac, got, tt.multicast)
+		}
+		if got := LocalMAC(tt.mac); got != tt.local {
+			t.Errorf("LocalMAC(%q) = %v, want %v", tt.mac, got, tt.local)
+		}
+		if got := UniversalMAC(tt.mac); got != tt.universal {
+			t.Errorf("UniversalMAC(%q) = %v, want %v", tt.mac, got, tt.universal)
+		}
+	}
+}
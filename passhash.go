@@ -0,0 +1,357 @@
+package is
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrBadPasswordHash       = errors.New("password hash: malformed")
+	ErrPasswordHashAlgorithm = errors.New("password hash: algorithm not allowed")
+	ErrPasswordHashParams    = errors.New("password hash: parameter out of range")
+)
+
+var (
+	bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+	// pbkdf2DigestSizes 是 PBKDF2 使用的 HMAC 摘要算法及其摘要长度
+	pbkdf2DigestSizes = map[string]int{"sha1": 20, "sha256": 32, "sha512": 64}
+)
+
+// ParsedPasswordHash 是解析后的密码哈希
+type ParsedPasswordHash struct {
+	// Algorithm 算法名，如 "bcrypt"、"argon2id"、"scrypt"、"pbkdf2-sha256"
+	Algorithm string
+	// Variant 格式变体，如 bcrypt 的 "2b"，PBKDF2 的 "passlib"、"django"
+	Variant string
+	// Version Argon2 的版本号（16 或 19），其它算法为 0
+	Version int
+	// Params 算法参数：bcrypt 为 "cost"；Argon2 为 "m"（KiB）、"t"、"p"；
+	// scrypt 为 "ln"、"r"、"p"；PBKDF2 为 "i"
+	Params map[string]int
+	Salt   []byte
+	Hash   []byte
+}
+
+// PasswordHashOptions 定义 ParsePasswordHash 的校验策略
+type PasswordHashOptions struct {
+	// Algorithms 允许的算法名，为空时不限制
+	Algorithms []string
+	// Min 按参数名限制参数的最小值，如 {"cost": 10, "i": 100000}
+	Min map[string]int
+	// Max 按参数名限制参数的最大值，如 {"cost": 14}
+	Max map[string]int
+}
+
+// ParsePasswordHash 识别并解析密码哈希，支持以下格式：
+//
+//	bcrypt       $2a$10$<22 位盐><31 位哈希>，另支持 $2b$ 与 $2y$
+//	Argon2       $argon2id$v=19$m=65536,t=3,p=4$<盐>$<哈希>（PHC 字符串，另支持 argon2i、argon2d）
+//	scrypt       $scrypt$ln=16,r=8,p=1$<盐>$<哈希>（PHC 字符串）
+//	PBKDF2       $pbkdf2-sha256$29000$<盐>$<哈希>（passlib，迭代次数也可写作 i=29000）
+//	             pbkdf2_sha256$600000$<盐>$<哈希>（Django）
+//
+// 除格式外还会检查各算法规范规定的参数范围，以及 opts 给出的策略。
+func ParsePasswordHash(s string, opts PasswordHashOptions) (*ParsedPasswordHash, error) {
+	var h *ParsedPasswordHash
+	var err error
+	switch {
+	case strings.HasPrefix(s, "$2"):
+		h, err = parseBcrypt(s)
+	case strings.HasPrefix(s, "$argon2"):
+		h, err = parseArgon2(s)
+	case strings.HasPrefix(s, "$scrypt$"):
+		h, err = parseScrypt(s)
+	case strings.HasPrefix(s, "$pbkdf2"):
+		h, err = parsePasslibPBKDF2(s)
+	case strings.HasPrefix(s, "pbkdf2_"):
+		h, err = parseDjangoPBKDF2(s)
+	default:
+		err = ErrBadPasswordHash
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Algorithms) > 0 && !slices.Contains(opts.Algorithms, h.Algorithm) {
+		return nil, ErrPasswordHashAlgorithm
+	}
+	for name, v := range h.Params {
+		if min, ok := opts.Min[name]; ok && v < min {
+			return nil, ErrPasswordHashParams
+		}
+		if max, ok := opts.Max[name]; ok && v > max {
+			return nil, ErrPasswordHashParams
+		}
+	}
+	return h, nil
+}
+
+// PasswordHash 判断给出的字符串是否为格式正确的密码哈希
+func PasswordHash(s string) bool {
+	_, err := ParsePasswordHash(s, PasswordHashOptions{})
+	return err == nil
+}
+
+// Bcrypt 判断给出的字符串是否为 bcrypt 哈希，cost 需要在 minCost 与 maxCost 之间，
+// maxCost 为 0 时不限制上限
+func Bcrypt(s string, minCost, maxCost int) bool {
+	opts := PasswordHashOptions{
+		Algorithms: []string{"bcrypt"},
+		Min:        map[string]int{"cost": minCost},
+	}
+	if maxCost > 0 {
+		opts.Max = map[string]int{"cost": maxCost}
+	}
+	_, err := ParsePasswordHash(s, opts)
+	return err == nil
+}
+
+// Argon2 判断给出的字符串是否为 Argon2 的 PHC 字符串
+func Argon2(s string) bool {
+	h, err := ParsePasswordHash(s, PasswordHashOptions{})
+	return err == nil && strings.HasPrefix(h.Algorithm, "argon2")
+}
+
+// Scrypt 判断给出的字符串是否为 scrypt 的 PHC 字符串
+func Scrypt(s string) bool {
+	h, err := ParsePasswordHash(s, PasswordHashOptions{})
+	return err == nil && h.Algorithm == "scrypt"
+}
+
+// PBKDF2 判断给出的字符串是否为 passlib 或 Django 格式的 PBKDF2 哈希
+func PBKDF2(s string) bool {
+	h, err := ParsePasswordHash(s, PasswordHashOptions{})
+	return err == nil && strings.HasPrefix(h.Algorithm, "pbkdf2")
+}
+
+func parseBcrypt(s string) (*ParsedPasswordHash, error) {
+	// $2b$10$ 加 53 个字符
+	if len(s) != 60 || s[3] != '$' || s[6] != '$' {
+		return nil, ErrBadPasswordHash
+	}
+	variant := s[1:3]
+	if variant != "2a" && variant != "2b" && variant != "2y" {
+		return nil, ErrBadPasswordHash
+	}
+	// cost 固定为两位十进制数字，strconv.Atoi 会接受 "+9" 这样的写法
+	if s[4] < '0' || s[4] > '9' || s[5] < '0' || s[5] > '9' {
+		return nil, ErrBadPasswordHash
+	}
+	cost := int(s[4]-'0')*10 + int(s[5]-'0')
+	if cost < 4 || cost > 31 {
+		return nil, ErrBadPasswordHash
+	}
+	salt, err1 := bcryptEncoding.DecodeString(s[7:29])
+	hash, err2 := bcryptEncoding.DecodeString(s[29:])
+	if err1 != nil || err2 != nil {
+		return nil, ErrBadPasswordHash
+	}
+	return &ParsedPasswordHash{
+		Algorithm: "bcrypt",
+		Variant:   variant,
+		Params:    map[string]int{"cost": cost},
+		Salt:      salt,
+		Hash:      hash,
+	}, nil
+}
+
+// phcString 是按照 PHC 字符串格式拆分后的各部分
+type phcString struct {
+	id      string
+	version string
+	params  []string
+	salt    string
+	hash    string
+}
+
+// splitPHC 拆分 $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+func splitPHC(s string) (phcString, bool) {
+	fields := strings.Split(s, "$")
+	if len(fields) < 2 || fields[0] != "" || fields[1] == "" {
+		return phcString{}, false
+	}
+	p := phcString{id: fields[1]}
+	fields = fields[2:]
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		p.version = fields[0][2:]
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && strings.Contains(fields[0], "=") {
+		p.params = strings.Split(fields[0], ",")
+		fields = fields[1:]
+	}
+	switch len(fields) {
+	case 2:
+		p.salt, p.hash = fields[0], fields[1]
+	case 1:
+		p.salt = fields[0]
+	case 0:
+	default:
+		return phcString{}, false
+	}
+	return p, true
+}
+
+// parsePHCParams 按照给出的顺序解析必需的十进制参数，optional 中的参数可以出现在末尾且不做解析
+func parsePHCParams(params []string, names []string, optional ...string) (map[string]int, bool) {
+	if len(params) < len(names) {
+		return nil, false
+	}
+	values := make(map[string]int, len(names))
+	for i, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, false
+		}
+		if i >= len(names) {
+			if !slices.Contains(optional, name) {
+				return nil, false
+			}
+			continue
+		}
+		if name != names[i] || (len(value) > 1 && value[0] == '0') {
+			return nil, false
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		values[name] = n
+	}
+	return values, true
+}
+
+func parseArgon2(s string) (*ParsedPasswordHash, error) {
+	p, ok := splitPHC(s)
+	if !ok || (p.id != "argon2i" && p.id != "argon2d" && p.id != "argon2id") {
+		return nil, ErrBadPasswordHash
+	}
+	h := &ParsedPasswordHash{Algorithm: p.id, Version: 16}
+	if p.version != "" {
+		switch p.version {
+		case "16", "19":
+			h.Version, _ = strconv.Atoi(p.version)
+		default:
+			return nil, ErrBadPasswordHash
+		}
+	}
+	if h.Params, ok = parsePHCParams(p.params, []string{"m", "t", "p"}, "keyid", "data"); !ok {
+		return nil, ErrBadPasswordHash
+	}
+	// 参数范围参考 Argon2 规范：1 <= p < 2^24，t >= 1，8p <= m < 2^32
+	m, t, par := h.Params["m"], h.Params["t"], h.Params["p"]
+	if par < 1 || par >= 1<<24 || t < 1 || m < 8*par || uint64(m) >= 1<<32 {
+		return nil, ErrBadPasswordHash
+	}
+	var err error
+	if h.Salt, h.Hash, err = decodePHCSaltHash(p, 8, 4); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func parseScrypt(s string) (*ParsedPasswordHash, error) {
+	p, ok := splitPHC(s)
+	if !ok || p.version != "" {
+		return nil, ErrBadPasswordHash
+	}
+	h := &ParsedPasswordHash{Algorithm: "scrypt"}
+	if h.Params, ok = parsePHCParams(p.params, []string{"ln", "r", "p"}); !ok {
+		return nil, ErrBadPasswordHash
+	}
+	// N = 2^ln 必须大于 1，且 r * p < 2^30
+	ln, r, par := h.Params["ln"], h.Params["r"], h.Params["p"]
+	if ln < 1 || ln > 63 || r < 1 || par < 1 || uint64(r)*uint64(par) >= 1<<30 {
+		return nil, ErrBadPasswordHash
+	}
+	var err error
+	if h.Salt, h.Hash, err = decodePHCSaltHash(p, 1, 1); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// decodePHCSaltHash 解码 PHC 字符串中不带填充的 base64 盐与哈希，并检查最小长度
+func decodePHCSaltHash(p phcString, minSalt, minHash int) (salt, hash []byte, err error) {
+	salt, err1 := base64.RawStdEncoding.DecodeString(p.salt)
+	hash, err2 := base64.RawStdEncoding.DecodeString(p.hash)
+	if err1 != nil || err2 != nil || len(salt) < minSalt || len(hash) < minHash {
+		return nil, nil, ErrBadPasswordHash
+	}
+	return salt, hash, nil
+}
+
+// parsePasslibPBKDF2 解析 passlib 格式的 PBKDF2 哈希，盐与哈希使用以 "." 代替 "+" 的 base64
+func parsePasslibPBKDF2(s string) (*ParsedPasswordHash, error) {
+	fields := strings.Split(s, "$")
+	if len(fields) != 5 || fields[0] != "" {
+		return nil, ErrBadPasswordHash
+	}
+	digest := "sha1"
+	if fields[1] != "pbkdf2" {
+		var ok bool
+		if digest, ok = strings.CutPrefix(fields[1], "pbkdf2-"); !ok {
+			return nil, ErrBadPasswordHash
+		}
+	}
+	size, ok := pbkdf2DigestSizes[digest]
+	if !ok {
+		return nil, ErrBadPasswordHash
+	}
+	iter, ok := parseIterations(strings.TrimPrefix(fields[2], "i="))
+	if !ok {
+		return nil, ErrBadPasswordHash
+	}
+	ab64 := strings.NewReplacer(".", "+")
+	salt, err1 := base64.RawStdEncoding.DecodeString(ab64.Replace(fields[3]))
+	hash, err2 := base64.RawStdEncoding.DecodeString(ab64.Replace(fields[4]))
+	if err1 != nil || err2 != nil || len(salt) == 0 || len(hash) != size {
+		return nil, ErrBadPasswordHash
+	}
+	return &ParsedPasswordHash{
+		Algorithm: "pbkdf2-" + digest,
+		Variant:   "passlib",
+		Params:    map[string]int{"i": iter},
+		Salt:      salt,
+		Hash:      hash,
+	}, nil
+}
+
+// parseDjangoPBKDF2 解析 Django 格式的 PBKDF2 哈希，盐为明文，哈希使用带填充的 base64
+func parseDjangoPBKDF2(s string) (*ParsedPasswordHash, error) {
+	fields := strings.Split(s, "$")
+	if len(fields) != 4 {
+		return nil, ErrBadPasswordHash
+	}
+	digest := strings.TrimPrefix(fields[0], "pbkdf2_")
+	size, ok := pbkdf2DigestSizes[digest]
+	if !ok {
+		return nil, ErrBadPasswordHash
+	}
+	iter, ok := parseIterations(fields[1])
+	if !ok || fields[2] == "" || !Alphanumeric(fields[2]) {
+		return nil, ErrBadPasswordHash
+	}
+	hash, err := base64.StdEncoding.DecodeString(fields[3])
+	if err != nil || len(hash) != size {
+		return nil, ErrBadPasswordHash
+	}
+	return &ParsedPasswordHash{
+		Algorithm: "pbkdf2-" + digest,
+		Variant:   "django",
+		Params:    map[string]int{"i": iter},
+		Salt:      []byte(fields[2]),
+		Hash:      hash,
+	}, nil
+}
+
+func parseIterations(s string) (int, bool) {
+	if s == "" || s[0] == '0' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n > 0
+}
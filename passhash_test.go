@@ -0,0 +1,120 @@
+package is
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePasswordHash(t *testing.T) {
+	sha1 := strings.Repeat("A", 27)
+	sha256 := strings.Repeat("A", 43)
+	sha512 := strings.Repeat("A", 86)
+	tests := []struct {
+		hash      string
+		algorithm string // 为空表示应当解析失败
+	}{
+		{"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "bcrypt"},
+		{"$2b$04$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "bcrypt"},
+		{"$2x$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2a$03$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhW", ""},
+		{"$2a$+9$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2a$ 9$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2a$-4$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2a$32$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", ""},
+		{"$2y$31$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", "bcrypt"},
+
+		{"$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id"},
+		{"$argon2i$m=4096,t=3,p=1$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2i"},
+		{"$argon2id$v=18$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", ""},
+		{"$argon2id$v=19$m=16,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", ""},
+		{"$argon2id$v=19$t=3,m=65536,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", ""},
+		{"$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", ""},
+
+		{"$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E", "scrypt"},
+		{"$scrypt$ln=0,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E", ""},
+		{"$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$", ""},
+
+		{"$pbkdf2$1000$c2FsdA$" + sha1, "pbkdf2-sha1"},
+		{"$pbkdf2-sha256$29000$N2YMIWQsBWBMae09x1jrPQ$" + sha256, "pbkdf2-sha256"},
+		{"$pbkdf2-sha256$i=29000$N2YMIWQsBWBMae09x1jrPQ$" + sha256, "pbkdf2-sha256"},
+		{"$pbkdf2-sha512$25000$LyWE0HrL.YPwPw$" + sha512, "pbkdf2-sha512"},
+		{"$pbkdf2-foo$1000$c2FsdA$", ""},
+		{"$pbkdf2-md5$1000$c2FsdA$" + strings.Repeat("A", 22), ""},
+		{"$pbkdf2-sha256$1000$c2FsdA$" + sha1, ""},
+		{"$pbkdf2-sha256$01000$c2FsdA$" + sha256, ""},
+		{"$pbkdf2-sha256$1000$$" + sha256, ""},
+		{"$pbkdf2sha256$1000$c2FsdA$" + sha256, ""},
+
+		{"pbkdf2_sha256$600000$abcdefgh12345678$" + sha256 + "=", "pbkdf2-sha256"},
+		{"pbkdf2_sha1$260000$salt$" + sha1 + "=", "pbkdf2-sha1"},
+		{"pbkdf2_md5$1000$abc$", ""},
+		{"pbkdf2_$1000$abc$", ""},
+		{"pbkdf2_sha256$600000$$" + sha256 + "=", ""},
+		{"pbkdf2_sha256$600000$sa-lt$" + sha256 + "=", ""},
+		{"pbkdf2_sha256$600000$salt$" + sha256, ""},
+		{"pbkdf2_sha256$0$salt$" + sha256 + "=", ""},
+
+		{"", ""},
+		{"plaintext", ""},
+		{"$md5$salt$hash", ""},
+	}
+	for _, tt := range tests {
+		h, err := ParsePasswordHash(tt.hash, PasswordHashOptions{})
+		if tt.algorithm == "" {
+			if err == nil {
+				t.Errorf("ParsePasswordHash(%q) = %s, want error", tt.hash, h.Algorithm)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePasswordHash(%q) = %v", tt.hash, err)
+		} else if h.Algorithm != tt.algorithm {
+			t.Errorf("ParsePasswordHash(%q).Algorithm = %s, want %s", tt.hash, h.Algorithm, tt.algorithm)
+		}
+	}
+}
+
+func TestPasswordHashOptions(t *testing.T) {
+	const django = "pbkdf2_sha256$260000$salt$" + "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+	tests := []struct {
+		opts PasswordHashOptions
+		want error
+	}{
+		{PasswordHashOptions{}, nil},
+		{PasswordHashOptions{Algorithms: []string{"pbkdf2-sha256", "argon2id"}}, nil},
+		{PasswordHashOptions{Algorithms: []string{"bcrypt"}}, ErrPasswordHashAlgorithm},
+		{PasswordHashOptions{Min: map[string]int{"i": 260000}}, nil},
+		{PasswordHashOptions{Min: map[string]int{"i": 600000}}, ErrPasswordHashParams},
+		{PasswordHashOptions{Max: map[string]int{"i": 100000}}, ErrPasswordHashParams},
+	}
+	for _, tt := range tests {
+		if _, err := ParsePasswordHash(django, tt.opts); err != tt.want {
+			t.Errorf("ParsePasswordHash(%+v) = %v, want %v", tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestBcrypt(t *testing.T) {
+	const hash = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+	tests := []struct {
+		min, max int
+		want     bool
+	}{
+		{10, 10, true},
+		{4, 31, true},
+		{12, 14, false},
+		{4, 9, false},
+		{0, 0, true},
+		{10, 0, true},
+		{11, 0, false},
+	}
+	for _, tt := range tests {
+		if got := Bcrypt(hash, tt.min, tt.max); got != tt.want {
+			t.Errorf("Bcrypt(%d, %d) = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+	if Bcrypt("$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", 0, 0) {
+		t.Error("Bcrypt accepted an Argon2 hash")
+	}
+}
@@ -0,0 +1,427 @@
+package is
+
+import (
+	_ "embed"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordTooShort  = errors.New("password: too short")
+	ErrPasswordTooLong   = errors.New("password: too long")
+	ErrPasswordNoUpper   = errors.New("password: missing uppercase letter")
+	ErrPasswordNoLower   = errors.New("password: missing lowercase letter")
+	ErrPasswordNoDigit   = errors.New("password: missing digit")
+	ErrPasswordNoSymbol  = errors.New("password: missing symbol")
+	ErrPasswordClasses   = errors.New("password: too few character classes")
+	ErrPasswordRepeat    = errors.New("password: too many repeated characters")
+	ErrPasswordSequence  = errors.New("password: too many sequential characters")
+	ErrPasswordCommon    = errors.New("password: too common")
+	ErrPasswordUserInfo  = errors.New("password: contains user information")
+	ErrPasswordWeak      = errors.New("password: too easy to guess")
+	commonPasswordsOnce  sync.Once
+	commonPasswordsRanks map[string]int
+)
+
+// maxScoredRunes 是 EstimatePasswordEntropy 估算的最大字符数
+const maxScoredRunes = 256
+
+// commonPasswordsText 是按常见程度排序的弱密码列表，每行一个
+//
+//go:embed common_passwords.txt
+var commonPasswordsText string
+
+// keyboardRows 是 QWERTY 键盘的未按 Shift 与按下 Shift 时的各行按键，
+// 除第一行外每行向右错开一列，用于判断按键是否相邻。
+var keyboardRows = [][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{" qwertyuiop[]\\", " QWERTYUIOP{}|"},
+	{" asdfghjkl;'", " ASDFGHJKL:\""},
+	{" zxcvbnm,./", " ZXCVBNM<>?"},
+}
+
+// keyboardPositions 记录每个字符在键盘上的行、列以及是否需要按下 Shift
+var keyboardPositions = func() map[rune][3]int {
+	m := make(map[rune][3]int)
+	for r, row := range keyboardRows {
+		for shift, keys := range row {
+			for c, k := range []rune(keys) {
+				if k != ' ' {
+					m[k] = [3]int{r, c, shift}
+				}
+			}
+		}
+	}
+	return m
+}()
+
+// leetSubstitutions 是常见的 l33t 替换字符
+var leetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '3': 'e', '9': 'g', '1': 'i', '!': 'i',
+	'|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+// PasswordPolicy 定义密码策略，零值不做任何限制
+type PasswordPolicy struct {
+	// MinLength 最小字符数
+	MinLength int
+	// MaxLength 最大字符数，为 0 时不限制
+	MaxLength int
+	// RequireUpper、RequireLower、RequireDigit、RequireSymbol 要求包含对应类别的字符
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MinClasses 至少包含的字符类别数（大写、小写、数字、符号）
+	MinClasses int
+	// MaxRepeat 同一字符最多连续出现的次数，为 0 时不限制
+	MaxRepeat int
+	// MaxSequence 连续递增或递减的字符（如 "abcd"、"4321"）的最大长度，为 0 时不限制
+	MaxSequence int
+	// ForbidCommon 拒绝常见弱密码列表中的密码，比较时忽略大小写
+	ForbidCommon bool
+	// MinEntropy 要求 EstimatePasswordEntropy 的估算结果不低于该值（比特），为 0 时不限制
+	MinEntropy float64
+}
+
+// Check 按照策略校验密码，userInputs 为用户名、邮箱等用户信息，
+// 密码包含其中任意一项（或邮箱的用户名部分）时会被拒绝，同时这些信息也会参与强度估算。
+func (p PasswordPolicy) Check(password string, userInputs ...string) error {
+	n := utf8.RuneCountInString(password)
+	if n < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if p.MaxLength > 0 && n > p.MaxLength {
+		return ErrPasswordTooLong
+	}
+	upper, lower, digit, symbol := passwordClasses(password)
+	switch {
+	case p.RequireUpper && !upper:
+		return ErrPasswordNoUpper
+	case p.RequireLower && !lower:
+		return ErrPasswordNoLower
+	case p.RequireDigit && !digit:
+		return ErrPasswordNoDigit
+	case p.RequireSymbol && !symbol:
+		return ErrPasswordNoSymbol
+	}
+	classes := boolToInt(upper) + boolToInt(lower) + boolToInt(digit) + boolToInt(symbol)
+	if int(classes) < p.MinClasses {
+		return ErrPasswordClasses
+	}
+	runes := []rune(password)
+	if p.MaxRepeat > 0 && longestRun(runes, 0) > p.MaxRepeat {
+		return ErrPasswordRepeat
+	}
+	if p.MaxSequence > 0 && (longestRun(runes, 1) > p.MaxSequence || longestRun(runes, -1) > p.MaxSequence) {
+		return ErrPasswordSequence
+	}
+	if p.ForbidCommon && CommonPassword(password) {
+		return ErrPasswordCommon
+	}
+	lowered := strings.ToLower(password)
+	for _, token := range userTokens(userInputs) {
+		if strings.Contains(lowered, token) {
+			return ErrPasswordUserInfo
+		}
+	}
+	if p.MinEntropy > 0 && EstimatePasswordEntropy(password, userInputs...) < p.MinEntropy {
+		return ErrPasswordWeak
+	}
+	return nil
+}
+
+// CommonPassword 判断给出的密码是否在常见弱密码列表中，比较时忽略大小写
+func CommonPassword(password string) bool {
+	_, ok := commonPasswords()[strings.ToLower(password)]
+	return ok
+}
+
+func commonPasswords() map[string]int {
+	commonPasswordsOnce.Do(func() {
+		commonPasswordsRanks = make(map[string]int)
+		for i, line := range strings.Split(commonPasswordsText, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				if _, ok := commonPasswordsRanks[line]; !ok {
+					commonPasswordsRanks[line] = i + 1
+				}
+			}
+		}
+	})
+	return commonPasswordsRanks
+}
+
+func passwordClasses(password string) (upper, lower, digit, symbol bool) {
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	return
+}
+
+// longestRun 返回相邻字符码点之差恒为 step 的最长连续片段的长度，
+// step 为 0 时即为同一字符连续出现的最大次数。
+func longestRun(runes []rune, step rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+	longest, current := 1, 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i]-runes[i-1] == step && (step == 0 || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+			current++
+		} else {
+			current = 1
+		}
+		longest = max(longest, current)
+	}
+	return longest
+}
+
+// userTokens 从用户信息中提取需要检查的小写片段，邮箱会额外拆出 "@" 前的部分，
+// 少于 3 个字符的片段会被忽略。
+func userTokens(inputs []string) []string {
+	var tokens []string
+	for _, input := range inputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		candidates := []string{input}
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			candidates = append(candidates, local)
+		}
+		for _, c := range candidates {
+			if utf8.RuneCountInString(c) >= 3 {
+				tokens = append(tokens, c)
+			}
+		}
+	}
+	return tokens
+}
+
+// PasswordScore 按照 zxcvbn 的分级返回密码强度，取值 0 至 4：
+// 0 表示极易猜测（少于 10^3 次），4 表示很难猜测（超过 10^10 次）。
+func PasswordScore(password string, userInputs ...string) int {
+	bits := EstimatePasswordEntropy(password, userInputs...)
+	for score, limit := range []float64{3, 6, 8, 10} {
+		if bits < limit*math.Log2(10) {
+			return score
+		}
+	}
+	return 4
+}
+
+// EstimatePasswordEntropy 以类似 zxcvbn 的方式估算猜中密码所需尝试次数的对数（比特）。
+//
+// 密码会被拆分为若干片段，每个片段取以下模式中最容易猜测的一种：常见密码（忽略大小写、
+// 支持 l33t 替换与倒序）、用户信息、重复字符、递增或递减序列、键盘上相邻的按键，
+// 以及 1900 至 2099 年的年份；不属于任何模式的字符按其字符集大小暴力猜测。
+// 最终结果为所有拆分方式中各片段猜测次数之积的最小值。
+// 为限制计算量，只估算前 256 个字符，超出的部分不计入结果。
+func EstimatePasswordEntropy(password string, userInputs ...string) float64 {
+	runes := []rune(password)
+	if len(runes) > maxScoredRunes {
+		runes = runes[:maxScoredRunes]
+		password = string(runes)
+	}
+	n := len(runes)
+	if n == 0 {
+		return 0
+	}
+	dict := commonPasswords()
+	user := make(map[string]bool)
+	for _, token := range userTokens(userInputs) {
+		user[token] = true
+	}
+	cardinality := bruteforceCardinality(password)
+
+	// best[i] 为前 i 个字符的最小猜测次数（log2）
+	best := make([]float64, n+1)
+	for i := 1; i <= n; i++ {
+		best[i] = best[i-1] + math.Log2(cardinality)
+		// 模式匹配的片段长度不超过 64，避免超长密码的计算量过大
+		for j := max(0, i-64); j <= i-2; j++ {
+			if g := patternGuesses(runes[j:i], dict, user); g > 0 {
+				best[i] = math.Min(best[i], best[j]+math.Log2(g))
+			}
+		}
+	}
+	return best[n]
+}
+
+func bruteforceCardinality(password string) float64 {
+	upper, lower, digit, symbol := passwordClasses(password)
+	c := 0.0
+	if upper {
+		c += 26
+	}
+	if lower {
+		c += 26
+	}
+	if digit {
+		c += 10
+	}
+	if symbol {
+		c += 33
+	}
+	return c
+}
+
+// patternGuesses 返回片段命中的最容易猜测的模式所需的猜测次数，未命中任何模式时返回 0
+func patternGuesses(seg []rune, dict map[string]int, user map[string]bool) float64 {
+	guesses := 0.0
+	consider := func(g float64) {
+		if g > 0 && (guesses == 0 || g < guesses) {
+			guesses = g
+		}
+	}
+	consider(dictionaryGuesses(seg, dict, user))
+	if len(seg) >= 3 {
+		consider(repeatGuesses(seg))
+		consider(sequenceGuesses(seg))
+		consider(spatialGuesses(seg))
+	}
+	consider(yearGuesses(seg))
+	return guesses
+}
+
+func dictionaryGuesses(seg []rune, dict map[string]int, user map[string]bool) float64 {
+	word := strings.ToLower(string(seg))
+	factor := 1.0
+	if word != string(seg) {
+		// 包含大写字母
+		factor *= 2
+	}
+	lookup := func(w string) float64 {
+		if user[w] {
+			return 1
+		}
+		if rank, ok := dict[w]; ok {
+			return float64(rank)
+		}
+		return 0
+	}
+	best := 0.0
+	consider := func(g float64) {
+		if g > 0 && (best == 0 || g < best) {
+			best = g
+		}
+	}
+	consider(lookup(word))
+	reversed := []rune(word)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	consider(lookup(string(reversed)) * 2)
+	unleet := []rune(word)
+	changed := false
+	for i, r := range unleet {
+		if sub, ok := leetSubstitutions[r]; ok {
+			unleet[i], changed = sub, true
+		}
+	}
+	if changed {
+		consider(lookup(string(unleet)) * 2)
+	}
+	return best * factor
+}
+
+func repeatGuesses(seg []rune) float64 {
+	if longestRun(seg, 0) != len(seg) {
+		return 0
+	}
+	return bruteforceCardinality(string(seg[:1])) * float64(len(seg))
+}
+
+func sequenceGuesses(seg []rune) float64 {
+	step := seg[1] - seg[0]
+	if step != 1 && step != -1 || longestRun(seg, step) != len(seg) {
+		return 0
+	}
+	start := 26.0
+	switch seg[0] {
+	case 'a', 'A', 'z', 'Z', '0', '1', '9':
+		start = 4
+	default:
+		if unicode.IsDigit(seg[0]) {
+			start = 10
+		}
+	}
+	if step < 0 {
+		start *= 2
+	}
+	return start * float64(len(seg))
+}
+
+// spatialGuesses 估算键盘相邻按键组成的片段（如 "qwerty"、"1qaz"）的猜测次数
+func spatialGuesses(seg []rune) float64 {
+	turns, shifted := 1, 0
+	lastDir := [2]int{}
+	for i := 1; i < len(seg); i++ {
+		a, ok1 := keyboardPositions[seg[i-1]]
+		b, ok2 := keyboardPositions[seg[i]]
+		if !ok1 || !ok2 {
+			return 0
+		}
+		dir := [2]int{b[0] - a[0], b[1] - a[1]}
+		if !keyboardAdjacent(dir) {
+			return 0
+		}
+		if i > 1 && dir != lastDir {
+			turns++
+		}
+		lastDir = dir
+	}
+	for _, r := range seg {
+		shifted += keyboardPositions[r][2]
+	}
+	// 与 zxcvbn 相同：起始按键数 s，平均相邻按键数 d，长度 L、转向 t 的组合数
+	const s, d = 47.0, 4.6
+	guesses := 0.0
+	for i := 2; i <= len(seg); i++ {
+		for j := 1; j <= min(turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * s * math.Pow(d, float64(j))
+		}
+	}
+	if shifted > 0 {
+		guesses *= 2
+	}
+	return guesses
+}
+
+// keyboardAdjacent 判断行、列差值是否对应错列键盘上的相邻按键
+func keyboardAdjacent(dir [2]int) bool {
+	switch dir {
+	case [2]int{0, 1}, [2]int{0, -1}, [2]int{-1, 0}, [2]int{-1, 1}, [2]int{1, 0}, [2]int{1, -1}:
+		return true
+	}
+	return false
+}
+
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
+
+func yearGuesses(seg []rune) float64 {
+	if len(seg) != 4 {
+		return 0
+	}
+	s := string(seg)
+	if (strings.HasPrefix(s, "19") || strings.HasPrefix(s, "20")) && Number(s) {
+		return 200
+	}
+	return 0
+}
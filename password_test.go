@@ -0,0 +1,104 @@
+package is
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		policy   PasswordPolicy
+		password string
+		inputs   []string
+		want     error
+	}{
+		{PasswordPolicy{}, "", nil, nil},
+		{PasswordPolicy{MinLength: 8}, "Ab1!xyz", nil, ErrPasswordTooShort},
+		{PasswordPolicy{MinLength: 8}, "密码密码密码密码", nil, nil},
+		{PasswordPolicy{MaxLength: 8}, "Ab1!xyzw9", nil, ErrPasswordTooLong},
+		{PasswordPolicy{RequireUpper: true}, "ab1!", nil, ErrPasswordNoUpper},
+		{PasswordPolicy{RequireLower: true}, "AB1!", nil, ErrPasswordNoLower},
+		{PasswordPolicy{RequireDigit: true}, "Ab!", nil, ErrPasswordNoDigit},
+		{PasswordPolicy{RequireSymbol: true}, "Ab1", nil, ErrPasswordNoSymbol},
+		{PasswordPolicy{RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}, "Ab1 ", nil, nil},
+		{PasswordPolicy{MinClasses: 3}, "abc123", nil, ErrPasswordClasses},
+		{PasswordPolicy{MinClasses: 3}, "abc123!", nil, nil},
+		{PasswordPolicy{MaxRepeat: 2}, "baaab", nil, ErrPasswordRepeat},
+		{PasswordPolicy{MaxRepeat: 3}, "baaab", nil, nil},
+		{PasswordPolicy{MaxSequence: 3}, "xabcdx", nil, ErrPasswordSequence},
+		{PasswordPolicy{MaxSequence: 3}, "x4321x", nil, ErrPasswordSequence},
+		{PasswordPolicy{MaxSequence: 3}, "xabcx", nil, nil},
+		{PasswordPolicy{MaxSequence: 2}, "x-./x", nil, nil},
+		{PasswordPolicy{ForbidCommon: true}, "PassWord", nil, ErrPasswordCommon},
+		{PasswordPolicy{ForbidCommon: true}, "123456", nil, ErrPasswordCommon},
+		{PasswordPolicy{ForbidCommon: true}, "kqzvxjwmp", nil, nil},
+		{PasswordPolicy{}, "xJSmith99", []string{"jsmith"}, ErrPasswordUserInfo},
+		{PasswordPolicy{}, "jsmith-rocks", []string{"jsmith@example.com"}, ErrPasswordUserInfo},
+		{PasswordPolicy{}, "xx-jo-xx", []string{"jo"}, nil},
+		{PasswordPolicy{MinEntropy: 40}, "P@ssw0rd", nil, ErrPasswordWeak},
+		{PasswordPolicy{MinEntropy: 40}, "kqzvxjwmp", nil, nil},
+	}
+	for _, tt := range tests {
+		if err := tt.policy.Check(tt.password, tt.inputs...); !errors.Is(err, tt.want) {
+			t.Errorf("%+v.Check(%q) = %v, want %v", tt.policy, tt.password, err, tt.want)
+		}
+	}
+}
+
+func TestCommonPassword(t *testing.T) {
+	tests := map[string]bool{
+		"123456":    true,
+		"password":  true,
+		"PASSWORD":  true,
+		"kqzvxjwmp": false,
+		"":          false,
+	}
+	for password, want := range tests {
+		if got := CommonPassword(password); got != want {
+			t.Errorf("CommonPassword(%q) = %v, want %v", password, got, want)
+		}
+	}
+}
+
+func TestPasswordScore(t *testing.T) {
+	tests := []struct {
+		password string
+		inputs   []string
+		want     int
+	}{
+		{"", nil, 0},
+		{"password", nil, 0},
+		{"P@ssw0rd", nil, 0},
+		{"drowssap", nil, 0},
+		{"qwerty", nil, 0},
+		{"aaaaaaaa", nil, 0},
+		{"abcdefgh", nil, 0},
+		// 纯暴力猜测的数字，每位约 3.32 比特
+		{"7391", nil, 1},
+		{"7391826", nil, 2},
+		{"739182640", nil, 3},
+		{"73918264051", nil, 4},
+		{"Tr0ub4dor&3", nil, 4},
+		{"jsmithrocks", nil, 4},
+		{"jsmithrocks", []string{"jsmith@example.com"}, 2},
+	}
+	for _, tt := range tests {
+		if got := PasswordScore(tt.password, tt.inputs...); got != tt.want {
+			t.Errorf("PasswordScore(%q, %q) = %d (%.2f bits), want %d",
+				tt.password, tt.inputs, got, EstimatePasswordEntropy(tt.password, tt.inputs...), tt.want)
+		}
+	}
+}
+
+func TestEstimatePasswordEntropyLongInput(t *testing.T) {
+	// 超出 256 个字符的部分不计入结果
+	long := strings.Repeat("kqzvxjwmp", 1000)
+	if got, want := EstimatePasswordEntropy(long), EstimatePasswordEntropy(long[:256]); got != want {
+		t.Errorf("EstimatePasswordEntropy(long) = %v, want %v", got, want)
+	}
+	if got := EstimatePasswordEntropy(strings.Repeat("密", 1000)); math.IsNaN(got) || got <= 0 {
+		t.Errorf("EstimatePasswordEntropy(multibyte) = %v", got)
+	}
+}
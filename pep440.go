@@ -0,0 +1,227 @@
+package is
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Regex 来自 PEP 440 附录 B，匹配前需要去掉首尾空白
+var pep440Regex = regexp.MustCompile(`(?i)^v?` +
+	`(?:([0-9]+)!)?` + // 纪元
+	`([0-9]+(?:\.[0-9]+)*)` + // 发布号
+	`(?:[-_.]?(alpha|a|beta|b|preview|pre|c|rc)[-_.]?([0-9]+)?)?` + // 预发布
+	`(?:-([0-9]+)|[-_.]?(post|rev|r)[-_.]?([0-9]+)?)?` + // 后发布
+	`(?:[-_.]?(dev)[-_.]?([0-9]+)?)?` + // 开发版
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`) // 本地版本
+
+// PEP440Version 是符合 PEP 440 规范的 Python 包版本号
+type PEP440Version struct {
+	Epoch   uint64
+	Release []uint64
+	// Pre 规范化后的预发布标记，"a"、"b" 或 "rc"，为空表示不是预发布版本
+	Pre    string
+	PreNum uint64
+	// Post 表示是否为后发布版本
+	Post    bool
+	PostNum uint64
+	// Dev 表示是否为开发版本
+	Dev    bool
+	DevNum uint64
+	// Local 本地版本标签，以 "." 分隔的各部分均为小写
+	Local []string
+}
+
+// ParsePEP440 解析符合 PEP 440 规范的版本号，如 "1.0.0rc1"、"2!1.0.post2.dev3"
+func ParsePEP440(s string) (PEP440Version, error) {
+	m := pep440Regex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return PEP440Version{}, ErrBadVersion
+	}
+	var v PEP440Version
+	var err error
+	if m[1] != "" {
+		if v.Epoch, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+			return PEP440Version{}, ErrBadVersion
+		}
+	}
+	for _, part := range strings.Split(m[2], ".") {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return PEP440Version{}, ErrBadVersion
+		}
+		v.Release = append(v.Release, n)
+	}
+	if m[3] != "" {
+		switch strings.ToLower(m[3]) {
+		case "alpha", "a":
+			v.Pre = "a"
+		case "beta", "b":
+			v.Pre = "b"
+		default:
+			v.Pre = "rc"
+		}
+		if v.PreNum, err = parseOptionalUint(m[4]); err != nil {
+			return PEP440Version{}, ErrBadVersion
+		}
+	}
+	if m[5] != "" || m[6] != "" {
+		v.Post = true
+		if v.PostNum, err = parseOptionalUint(m[5] + m[7]); err != nil {
+			return PEP440Version{}, ErrBadVersion
+		}
+	}
+	if m[8] != "" {
+		v.Dev = true
+		if v.DevNum, err = parseOptionalUint(m[9]); err != nil {
+			return PEP440Version{}, ErrBadVersion
+		}
+	}
+	if m[10] != "" {
+		v.Local = strings.FieldsFunc(strings.ToLower(m[10]), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+	return v, nil
+}
+
+// PEP440 判断给出的字符串是否为符合 PEP 440 规范的版本号
+func PEP440(s string) bool {
+	_, err := ParsePEP440(s)
+	return err == nil
+}
+
+func parseOptionalUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// String 返回规范化后的版本号，如 "1.0.0-RC.1" 规范化为 "1.0.0rc1"
+func (v PEP440Version) String() string {
+	var b strings.Builder
+	if v.Epoch > 0 {
+		b.WriteString(strconv.FormatUint(v.Epoch, 10))
+		b.WriteByte('!')
+	}
+	for i, n := range v.Release {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(n, 10))
+	}
+	if v.Pre != "" {
+		b.WriteString(v.Pre)
+		b.WriteString(strconv.FormatUint(v.PreNum, 10))
+	}
+	if v.Post {
+		b.WriteString(".post")
+		b.WriteString(strconv.FormatUint(v.PostNum, 10))
+	}
+	if v.Dev {
+		b.WriteString(".dev")
+		b.WriteString(strconv.FormatUint(v.DevNum, 10))
+	}
+	if len(v.Local) > 0 {
+		b.WriteByte('+')
+		b.WriteString(strings.Join(v.Local, "."))
+	}
+	return b.String()
+}
+
+// Compare 按照 PEP 440 的规则比较两个版本，v 小于、等于、大于 o 时
+// 分别返回 -1、0、1。发布号末尾的零不影响比较，因此 "1.0" 等于 "1.0.0"；
+// 同一发布号下的顺序为 dev < a < b < rc < 正式版 < post。
+func (v PEP440Version) Compare(o PEP440Version) int {
+	if c := compareUint(v.Epoch, o.Epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(v.Release) || i < len(o.Release); i++ {
+		var x, y uint64
+		if i < len(v.Release) {
+			x = v.Release[i]
+		}
+		if i < len(o.Release) {
+			y = o.Release[i]
+		}
+		if c := compareUint(x, y); c != 0 {
+			return c
+		}
+	}
+	if c := compareInt(v.preRank(), o.preRank()); c != 0 {
+		return c
+	}
+	if v.Pre != "" {
+		if c := compareUint(v.PreNum, o.PreNum); c != 0 {
+			return c
+		}
+	}
+	if c := compareBool(v.Post, o.Post); c != 0 {
+		return c
+	}
+	if c := compareUint(v.PostNum, o.PostNum); c != 0 {
+		return c
+	}
+	if c := compareBool(!v.Dev, !o.Dev); c != 0 {
+		return c
+	}
+	if c := compareUint(v.DevNum, o.DevNum); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, o.Local)
+}
+
+// preRank 返回预发布阶段的排序值，仅有开发版标记的版本排在所有预发布版本之前
+func (v PEP440Version) preRank() int {
+	switch v.Pre {
+	case "a":
+		return 1
+	case "b":
+		return 2
+	case "rc":
+		return 3
+	}
+	if v.Dev && !v.Post {
+		return 0
+	}
+	return 4
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	return compareInt(int(boolToInt(a)), int(boolToInt(b)))
+}
+
+// compareLocal 比较本地版本标签：没有标签的版本更小；
+// 数字部分按数值比较且大于字母部分，字母部分按字符串比较。
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, errX := strconv.ParseUint(a[i], 10, 64)
+		y, errY := strconv.ParseUint(b[i], 10, 64)
+		var c int
+		switch {
+		case errX == nil && errY == nil:
+			c = compareUint(x, y)
+		case errX == nil:
+			c = 1
+		case errY == nil:
+			c = -1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a), len(b))
+}
@@ -0,0 +1,112 @@
+package is
+
+import "testing"
+
+func TestParsePEP440(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string // 规范化后的版本号，为空表示应当解析失败
+	}{
+		{"1.0", "1.0"},
+		{"v1.0", "1.0"},
+		{" 1.0.0 ", "1.0.0"},
+		{"2!1.0.post2.dev3", "2!1.0.post2.dev3"},
+		{"1.0.0rc1", "1.0.0rc1"},
+		{"1.0-RC.1", "1.0rc1"},
+		{"1.0c1", "1.0rc1"},
+		{"1.0preview2", "1.0rc2"},
+		{"1.0alpha", "1.0a0"},
+		{"1.0.Beta-3", "1.0b3"},
+		{"1.0-1", "1.0.post1"},
+		{"1.0.post", "1.0.post0"},
+		{"1.0rev4", "1.0.post4"},
+		{"1.0r", "1.0.post0"},
+		{"1.0.dev", "1.0.dev0"},
+		{"1.0a2.dev456", "1.0a2.dev456"},
+		{"1.0+Ubuntu-1", "1.0+ubuntu.1"},
+		{"1.0+abc_5.7", "1.0+abc.5.7"},
+
+		{"", ""},
+		{"1.0.", ""},
+		{"1..0", ""},
+		{"a1.0", ""},
+		{"1.0+", ""},
+		{"1.0+a..b", ""},
+		{"1.0-", ""},
+		{"1.0.gamma1", ""},
+		{"1!", ""},
+		{"99999999999999999999.0", ""},
+	}
+	for _, tt := range tests {
+		v, err := ParsePEP440(tt.s)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParsePEP440(%q) = %s, want error", tt.s, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePEP440(%q) = %v", tt.s, err)
+		} else if got := v.String(); got != tt.want {
+			t.Errorf("ParsePEP440(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPEP440Compare(t *testing.T) {
+	// PEP 440 "Summary of permitted suffixes and relative ordering" 中的示例，按升序排列
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"2.0",
+		"1!0.1",
+	}
+	versions := make([]PEP440Version, len(ordered))
+	for i, s := range ordered {
+		v, err := ParsePEP440(s)
+		if err != nil {
+			t.Fatalf("ParsePEP440(%q) = %v", s, err)
+		}
+		versions[i] = v
+	}
+	for i := range versions {
+		for j := range versions {
+			want := compareInt(i, j)
+			if got := versions[i].Compare(versions[j]); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	equal := [][2]string{
+		{"1.0", "1.0.0"},
+		{"1.0", "v1.0.0.0"},
+		{"1.0rc1", "1.0-RC.1"},
+		{"1.0.post0", "1.0-0"},
+		{"0!1.0", "1.0"},
+	}
+	for _, pair := range equal {
+		a, _ := ParsePEP440(pair[0])
+		b, _ := ParsePEP440(pair[1])
+		if c := a.Compare(b); c != 0 {
+			t.Errorf("Compare(%s, %s) = %d, want 0", pair[0], pair[1], c)
+		}
+	}
+}
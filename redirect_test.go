@@ -0,0 +1,49 @@
+package is
+
+import "testing"
+
+func TestCheckRedirectURL(t *testing.T) {
+	allowed := []string{"example.com", "*.trusted.org"}
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"/", nil},
+		{"/home?tab=1#top", nil},
+		{"account/settings", nil},
+		{"?next=1", nil},
+		{"https://example.com/callback", nil},
+		{"http://EXAMPLE.com./", nil},
+		{"https://example.com:8443/", nil},
+		{"https://app.trusted.org/", nil},
+		{"https://a.b.trusted.org/", nil},
+
+		{"", ErrURLEmpty},
+		{"//evil.com", ErrURLRedirect},
+		{"///evil.com", ErrURLRedirect},
+		{"/\\evil.com", ErrURLRedirect},
+		{"\\\\evil.com", ErrURLRedirect},
+		{"https:\\\\evil.com", ErrURLRedirect},
+		{"/%2fevil.com", ErrURLRedirect},
+		{"/%5Cevil.com", ErrURLRedirect},
+		{"/%252Fevil.com", ErrURLRedirect},
+		{" //evil.com", ErrURLRedirect},
+		{"/\t/evil.com", ErrURLRedirect},
+		{"/\r\n/evil.com", ErrURLRedirect},
+		{"javascript:alert(1)", ErrURLScheme},
+		{"data:text/html,hi", ErrURLScheme},
+		{"https:evil.com", ErrURLHost},
+		{"https://example.com@evil.com/", ErrURLUserinfo},
+		{"https://evil.com/", ErrURLHostNotAllowed},
+		{"https://example.com.evil.com/", ErrURLHostNotAllowed},
+		{"https://evilexample.com/", ErrURLHostNotAllowed},
+		{"https://trusted.org/", ErrURLHostNotAllowed},
+		{"https://evil.com#@example.com", ErrURLHostNotAllowed},
+		{"https://evil.com?@example.com", ErrURLHostNotAllowed},
+	}
+	for _, tt := range tests {
+		if err := CheckRedirectURL(tt.url, allowed); err != tt.want {
+			t.Errorf("CheckRedirectURL(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
@@ -9,12 +9,9 @@ const (
 	alphaUnicodeNumericRegexString = "^[\\p{L}\\p{N}]+$"
 	numericRegexString             = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
 	numberRegexString              = "^[0-9]+$"
+	decimalRegexString             = "^[-+]?(?:[0-9]+(?:\\.[0-9]+)?|\\.[0-9]+)(?:[eE][-+]?[0-9]+)?$"
 	hexadecimalRegexString         = "^(0[xX])?[0-9a-fA-F]+$"
 	hexColorRegexString            = "^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"
-	rgbRegexString                 = "^rgb\\(\\s*(?:(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])|(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%)\\s*\\)$"
-	rgbaRegexString                = "^rgba\\(\\s*(?:(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])|(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%\\s*,\\s*(?:0|[1-9]\\d?|1\\d\\d?|2[0-4]\\d|25[0-5])%)\\s*,\\s*(?:(?:0.[1-9]*)|[01])\\s*\\)$"
-	hslRegexString                 = "^hsl\\(\\s*(?:0|[1-9]\\d?|[12]\\d\\d|3[0-5]\\d|360)\\s*,\\s*(?:(?:0|[1-9]\\d?|100)%)\\s*,\\s*(?:(?:0|[1-9]\\d?|100)%)\\s*\\)$"
-	hslaRegexString                = "^hsla\\(\\s*(?:0|[1-9]\\d?|[12]\\d\\d|3[0-5]\\d|360)\\s*,\\s*(?:(?:0|[1-9]\\d?|100)%)\\s*,\\s*(?:(?:0|[1-9]\\d?|100)%)\\s*,\\s*(?:(?:0.[1-9]*)|[01])\\s*\\)$"
 	emailRegexString               = "^(?:(?:(?:(?:[a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+(?:\\.([a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+)*)|(?:(?:\\x22)(?:(?:(?:(?:\\x20|\\x09)*(?:\\x0d\\x0a))?(?:\\x20|\\x09)+)?(?:(?:[\\x01-\\x08\\x0b\\x0c\\x0e-\\x1f\\x7f]|\\x21|[\\x23-\\x5b]|[\\x5d-\\x7e]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(?:(?:[\\x01-\\x09\\x0b\\x0c\\x0d-\\x7f]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}]))))*(?:(?:(?:\\x20|\\x09)*(?:\\x0d\\x0a))?(\\x20|\\x09)+)?(?:\\x22))))@(?:(?:(?:[a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(?:(?:[a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])(?:[a-zA-Z]|\\d|-|\\.|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*(?:[a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.)+(?:(?:[a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(?:(?:[a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])(?:[a-zA-Z]|\\d|-|\\.|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*(?:[a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.?$"
 	e164RegexString                = "^\\+[1-9]?[0-9]{7,14}$"
 	phoneNumberRegexString         = "^(\\+?86)?1[0-9]{10}$"
@@ -24,15 +21,12 @@ const (
 	uUID4RegexString               = "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
 	uUID5RegexString               = "^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
 	uUIDRegexString                = "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"
-	uLIDRegexString                = "^[A-HJKMNP-TV-Z0-9]{26}$"
 	md4RegexString                 = "^[0-9a-f]{32}$"
 	md5RegexString                 = "^[0-9a-f]{32}$"
 	sha256RegexString              = "^[0-9a-f]{64}$"
 	sha384RegexString              = "^[0-9a-f]{96}$"
 	sha512RegexString              = "^[0-9a-f]{128}$"
 	aSCIIRegexString               = "^[\x00-\x7F]*$"
-	latitudeRegexString            = "^[-+]?([1-8]?\\d(\\.\\d+)?|90(\\.0+)?)$"
-	longitudeRegexString           = "^[-+]?(180(\\.0+)?|((1[0-7]\\d)|([1-9]?\\d))(\\.\\d+)?)$"
 	uRLEncodedRegexString          = `^(?:[^%]|%[0-9A-Fa-f]{2})*$`
 	hTMLEncodedRegexString         = `&#[x]?([0-9a-fA-F]{2})|(&gt)|(&lt)|(&quot)|(&amp)+[;]?`
 	hTMLRegexString                = `<[/]?([a-zA-Z]+).*?>`
@@ -48,12 +42,9 @@ var (
 	alphaUnicodeNumericRegex = regexp.MustCompile(alphaUnicodeNumericRegexString)
 	numericRegex             = regexp.MustCompile(numericRegexString)
 	numberRegex              = regexp.MustCompile(numberRegexString)
+	decimalRegex             = regexp.MustCompile(decimalRegexString)
 	hexadecimalRegex         = regexp.MustCompile(hexadecimalRegexString)
 	hexColorRegex            = regexp.MustCompile(hexColorRegexString)
-	rgbRegex                 = regexp.MustCompile(rgbRegexString)
-	rgbaRegex                = regexp.MustCompile(rgbaRegexString)
-	hslRegex                 = regexp.MustCompile(hslRegexString)
-	hslaRegex                = regexp.MustCompile(hslaRegexString)
 	e164Regex                = regexp.MustCompile(e164RegexString)
 	phoneNumberRegex         = regexp.MustCompile(phoneNumberRegexString)
 	emailRegex               = regexp.MustCompile(emailRegexString)
@@ -63,15 +54,12 @@ var (
 	uUID4Regex               = regexp.MustCompile(uUID4RegexString)
 	uUID5Regex               = regexp.MustCompile(uUID5RegexString)
 	uUIDRegex                = regexp.MustCompile(uUIDRegexString)
-	uLIDRegex                = regexp.MustCompile(uLIDRegexString)
 	md4Regex                 = regexp.MustCompile(md4RegexString)
 	md5Regex                 = regexp.MustCompile(md5RegexString)
 	sha256Regex              = regexp.MustCompile(sha256RegexString)
 	sha384Regex              = regexp.MustCompile(sha384RegexString)
 	sha512Regex              = regexp.MustCompile(sha512RegexString)
 	aSCIIRegex               = regexp.MustCompile(aSCIIRegexString)
-	latitudeRegex            = regexp.MustCompile(latitudeRegexString)
-	longitudeRegex           = regexp.MustCompile(longitudeRegexString)
 	uRLEncodedRegex          = regexp.MustCompile(uRLEncodedRegexString)
 	hTMLEncodedRegex         = regexp.MustCompile(hTMLEncodedRegexString)
 	hTMLRegex                = regexp.MustCompile(hTMLRegexString)
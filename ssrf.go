@@ -0,0 +1,164 @@
+package is
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrURLResolve    = errors.New("url: host could not be resolved")
+	ErrURLUnsafeAddr = errors.New("url: host resolves to a disallowed address")
+
+	// embeddedIPv4Prefixes 是末尾 4 字节内嵌 IPv4 地址的 IPv6 地址段：
+	// NAT64 的知名前缀与本地前缀（RFC 6052、RFC 8215）以及已废弃的 IPv4 兼容地址
+	embeddedIPv4Prefixes = []netip.Prefix{
+		netip.MustParsePrefix("64:ff9b::/96"),
+		netip.MustParsePrefix("64:ff9b:1::/48"),
+		netip.MustParsePrefix("::/96"),
+	}
+)
+
+// Resolver 用于将主机名解析为 IP 地址，*net.Resolver 实现了该接口，
+// 测试时可以替换为返回固定结果的实现。
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// SafeURLOptions 定义 CheckSafeURL 的校验策略
+type SafeURLOptions struct {
+	// URLOptions 用于校验 URL 本身，Schemes 为空时只允许 http 和 https，
+	// 且总是要求包含主机。
+	URLOptions
+	// Resolver 用于解析主机名，为空时使用 net.DefaultResolver
+	Resolver Resolver
+	// Deny 额外拒绝的地址集合，例如内部服务所在的公网地址段
+	Deny *IPSet
+}
+
+// CheckSafeURL 校验 URL 并解析其主机，当主机指向私有、环回、链路本地等非公网地址，
+// 或者命中 Deny 集合时返回 ErrURLUnsafeAddr，可用于防范服务端请求伪造（SSRF）。
+//
+// "2130706433"、"0177.0.0.1" 这类以纯数字结尾的宽松写法不是有效的主机名，校验 URL 时即被拒绝；
+// "127.0x1"、"0x7f.0.0.0x1" 这类以十六进制结尾的写法会按照 inet_aton 的规则识别为 IP 地址。
+// IPv4 映射、IPv4 兼容以及 NAT64 的 IPv6 地址会同时检查内嵌的 IPv4 地址。
+//
+// 注意校验与实际请求之间存在时间差，主机的解析结果可能发生变化（DNS rebinding），
+// 发起请求时应使用校验得到的地址或在拨号阶段再次检查。
+func CheckSafeURL(ctx context.Context, s string, opts SafeURLOptions) error {
+	if len(opts.Schemes) == 0 {
+		opts.Schemes = []string{"http", "https"}
+	}
+	opts.RequireHost = true
+	opts.AllowRelative = false
+	u, err := checkURL(s, opts.URLOptions)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if ip, ok := parseHostIP(host); ok {
+		return checkSafeAddr(ip, opts.Deny)
+	}
+	resolver := opts.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return ErrURLResolve
+	}
+	for _, ip := range addrs {
+		if err = checkSafeAddr(ip, opts.Deny); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SafeURL 判断给出的 http 或 https URL 是否指向公网地址
+func SafeURL(s string) bool {
+	return CheckSafeURL(context.Background(), s, SafeURLOptions{}) == nil
+}
+
+func checkSafeAddr(ip netip.Addr, deny *IPSet) error {
+	ip = ip.Unmap()
+	if isReservedAddr(ip) || (deny != nil && deny.Contains(ip)) {
+		return ErrURLUnsafeAddr
+	}
+	if v4, ok := embeddedIPv4(ip); ok {
+		return checkSafeAddr(v4, deny)
+	}
+	return nil
+}
+
+// embeddedIPv4 提取 NAT64 或 IPv4 兼容地址中内嵌的 IPv4 地址
+func embeddedIPv4(ip netip.Addr) (netip.Addr, bool) {
+	if !ip.Is6() || !containsAddr(embeddedIPv4Prefixes, ip) {
+		return netip.Addr{}, false
+	}
+	b := ip.As16()
+	return netip.AddrFrom4([4]byte(b[12:])), true
+}
+
+// parseHostIP 识别 URL 主机部分中的 IP 地址，包括标准写法和 inet_aton 支持的宽松写法
+func parseHostIP(host string) (netip.Addr, bool) {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return ip.Unmap(), true
+	}
+	return parseLooseIPv4(strings.TrimSuffix(host, "."))
+}
+
+// parseLooseIPv4 按照 inet_aton 的规则解析 IPv4 地址：地址由 1 到 4 个部分组成，
+// 每部分可以是十进制、以 0 开头的八进制或以 0x 开头的十六进制数，
+// 最后一部分填充剩余的所有字节，如 "127.1" 等价于 "127.0.0.1"。
+func parseLooseIPv4(host string) (netip.Addr, bool) {
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return netip.Addr{}, false
+	}
+	var nums [4]uint64
+	for i, part := range parts {
+		n, ok := parseLooseUint(part)
+		if !ok {
+			return netip.Addr{}, false
+		}
+		nums[i] = n
+	}
+	last := len(parts) - 1
+	for i := 0; i < last; i++ {
+		if nums[i] > 0xff {
+			return netip.Addr{}, false
+		}
+	}
+	if nums[last] >= 1<<(8*(4-last)) {
+		return netip.Addr{}, false
+	}
+	var v uint64
+	for i := 0; i < last; i++ {
+		v |= nums[i] << (8 * (3 - i))
+	}
+	v |= nums[last]
+	return netip.AddrFrom4([4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}), true
+}
+
+func parseLooseUint(s string) (uint64, bool) {
+	base := 10
+	switch {
+	case len(s) > 1 && (s[:2] == "0x" || s[:2] == "0X"):
+		base, s = 16, s[2:]
+		if s == "" {
+			// inet_aton 将单独的 "0x" 视为 0
+			return 0, true
+		}
+	case len(s) > 1 && s[0] == '0':
+		base, s = 8, s[1:]
+	}
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, base, 32)
+	return n, err == nil
+}
@@ -0,0 +1,123 @@
+package is
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+// stubResolver 将主机名解析为固定的地址，未登记的主机返回错误
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	var ips []netip.Addr
+	for _, a := range addrs {
+		ips = append(ips, netip.MustParseAddr(a))
+	}
+	return ips, nil
+}
+
+func TestCheckSafeURL(t *testing.T) {
+	resolver := stubResolver{
+		"example.com":  {"93.184.215.14"},
+		"dual.example": {"93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c"},
+		"rebind.test":  {"93.184.215.14", "10.0.0.1"},
+		"local.test":   {"127.0.0.1"},
+		"nat64.test":   {"64:ff9b::a9fe:a9fe"},
+		"empty.test":   {},
+		"internal.com": {"203.0.114.10"},
+	}
+	deny, err := NewIPSet("203.0.114.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://example.com/", nil},
+		{"http://dual.example:8080/path", nil},
+		{"http://93.184.215.14/", nil},
+		{"http://[2606:2800:21f:cb07:6820:80da:af6b:8b2c]/", nil},
+		{"http://[64:ff9b::5db8:d70e]/", nil},
+
+		{"ftp://example.com/", ErrURLScheme},
+		{"/relative", ErrURLRelative},
+		{"http://unknown.test/", ErrURLResolve},
+		{"http://empty.test/", ErrURLResolve},
+		{"http://internal.com/", ErrURLUnsafeAddr},
+		{"http://203.0.114.1/", ErrURLUnsafeAddr},
+
+		{"http://127.0.0.1/", ErrURLUnsafeAddr},
+		{"http://localhost.test./", ErrURLResolve},
+		{"http://local.test/", ErrURLUnsafeAddr},
+		{"http://rebind.test/", ErrURLUnsafeAddr},
+		{"http://10.1.2.3/", ErrURLUnsafeAddr},
+		{"http://169.254.169.254/latest/meta-data/", ErrURLUnsafeAddr},
+		{"http://[::1]/", ErrURLUnsafeAddr},
+		{"http://[::]/", ErrURLUnsafeAddr},
+		{"http://[fe80::1%25eth0]/", ErrURLUnsafeAddr},
+		{"http://[fd00::1]/", ErrURLUnsafeAddr},
+		{"http://[::ffff:127.0.0.1]/", ErrURLUnsafeAddr},
+		{"http://[::ffff:7f00:1]/", ErrURLUnsafeAddr},
+
+		// 内嵌 IPv4 地址的 IPv6 地址
+		{"http://[64:ff9b::7f00:1]/", ErrURLUnsafeAddr},
+		{"http://[64:ff9b::10.0.0.1]/", ErrURLUnsafeAddr},
+		{"http://[64:ff9b:1::a00:1]/", ErrURLUnsafeAddr},
+		{"http://[::127.0.0.1]/", ErrURLUnsafeAddr},
+		{"http://[::a9fe:a9fe]/", ErrURLUnsafeAddr},
+		{"http://[64:ff9b::cb00:7201]/", ErrURLUnsafeAddr},
+		{"http://nat64.test/", ErrURLUnsafeAddr},
+
+		// inet_aton 的宽松写法
+		{"http://127.0x1/", ErrURLUnsafeAddr},
+		{"http://0x7f.0.0.0x1/", ErrURLUnsafeAddr},
+		{"http://0xa9fea9fe/", ErrURLUnsafeAddr},
+		{"http://2130706433/", ErrURLHost},
+		{"http://0177.0.0.1/", ErrURLHost},
+		{"http://127.1/", ErrURLHost},
+	}
+	for _, tt := range tests {
+		err := CheckSafeURL(context.Background(), tt.url, SafeURLOptions{Resolver: resolver, Deny: deny})
+		if err != tt.want {
+			t.Errorf("CheckSafeURL(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestParseLooseIPv4(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"127.1", "127.0.0.1"},
+		{"2130706433", "127.0.0.1"},
+		{"0x7f.1", "127.0.0.1"},
+		{"0177.0.0.1", "127.0.0.1"},
+		{"10.0x10203", "10.1.2.3"},
+		{"0x", "0.0.0.0"},
+		{"256.1", ""},
+		{"1.2.3.4.5", ""},
+		{"4294967296", ""},
+		{"08.0.0.1", ""},
+		{"1.-2", ""},
+		{"example", ""},
+	}
+	for _, tt := range tests {
+		ip, ok := parseLooseIPv4(tt.host)
+		if got := ""; ok {
+			got = ip.String()
+			if got != tt.want {
+				t.Errorf("parseLooseIPv4(%q) = %s, want %q", tt.host, got, tt.want)
+			}
+		} else if tt.want != "" {
+			t.Errorf("parseLooseIPv4(%q) failed, want %s", tt.host, tt.want)
+		}
+	}
+}
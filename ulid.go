@@ -0,0 +1,115 @@
+package is
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var (
+	ErrBadULID      = errors.New("ulid: invalid format")
+	ErrULIDOverflow = errors.New("ulid: value exceeds 128 bits")
+	ErrULIDFuture   = errors.New("ulid: timestamp is in the future")
+	ErrULIDTooOld   = errors.New("ulid: timestamp is too old")
+)
+
+// crockfordAlphabet 是 Crockford base32 的编码字符表
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordDecoding 是 Crockford base32 的解码表，不区分大小写，
+// 并按照规范将 I、L 视为 1，O 视为 0，无效字符为 0xff。
+var crockfordDecoding = func() (table [256]byte) {
+	for i := range table {
+		table[i] = 0xff
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		table[c] = byte(i)
+		table[c|0x20] = byte(i)
+	}
+	for _, c := range "IiLl" {
+		table[c] = 1
+	}
+	table['O'], table['o'] = 0, 0
+	return
+}()
+
+// ParsedULID 是解析后的 ULID
+type ParsedULID [16]byte
+
+// ULIDOptions 定义 CheckULID 对时间戳的约束
+type ULIDOptions struct {
+	// NotFuture 拒绝时间戳晚于当前时间的 ULID
+	NotFuture bool
+	// MaxAge 拒绝时间戳早于当前时间减去 MaxAge 的 ULID，为 0 时不限制
+	MaxAge time.Duration
+	// Now 返回当前时间，为空时使用 time.Now
+	Now func() time.Time
+}
+
+// ParseULID 解析 ULID，不区分大小写，超出 128 位（首字符大于 7）的值会被拒绝
+func ParseULID(s string) (ParsedULID, error) {
+	var u ParsedULID
+	if len(s) != 26 {
+		return u, ErrBadULID
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordDecoding[s[i]]
+		if v == 0xff {
+			return u, ErrBadULID
+		}
+		if i == 0 && v > 7 {
+			return u, ErrULIDOverflow
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// CheckULID 校验 ULID 及其时间戳，并返回具体的失败原因
+func CheckULID(s string, opts ULIDOptions) error {
+	u, err := ParseULID(s)
+	if err != nil {
+		return err
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	t := u.Time()
+	if opts.NotFuture && t.After(now()) {
+		return ErrULIDFuture
+	}
+	if opts.MaxAge > 0 && t.Before(now().Add(-opts.MaxAge)) {
+		return ErrULIDTooOld
+	}
+	return nil
+}
+
+// Timestamp 返回 ULID 中嵌入的 Unix 毫秒时间戳
+func (u ParsedULID) Timestamp() uint64 {
+	return uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+}
+
+// Time 返回 ULID 中嵌入的时间
+func (u ParsedULID) Time() time.Time {
+	return time.UnixMilli(int64(u.Timestamp())).UTC()
+}
+
+// String 返回大写的标准形式
+func (u ParsedULID) String() string {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
@@ -0,0 +1,95 @@
+package is
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseULID(t *testing.T) {
+	tests := []struct {
+		s         string
+		timestamp uint64
+		canonical string
+		want      error
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", 1469922850259, "01ARZ3NDEKTSV4RRFFQ69G5FAV", nil},
+		{"01arz3ndektsv4rrffq69g5fav", 1469922850259, "01ARZ3NDEKTSV4RRFFQ69G5FAV", nil},
+		{"00000000000000000000000000", 0, "00000000000000000000000000", nil},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", 1<<48 - 1, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", nil},
+		// Crockford 别名：I、L 视为 1，O 视为 0
+		{"0IL0000000000000000000000O", 1<<40 | 1<<35, "01100000000000000000000000", nil},
+
+		// 首字符大于 7 时超出 128 位
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", 0, "", ErrULIDOverflow},
+		{"80000000000000000000000000", 0, "", ErrULIDOverflow},
+		{"ZZZZZZZZZZZZZZZZZZZZZZZZZZ", 0, "", ErrULIDOverflow},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", 0, "", ErrBadULID},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAVV", 0, "", ErrBadULID},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", 0, "", ErrBadULID},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA-", 0, "", ErrBadULID},
+		{"", 0, "", ErrBadULID},
+	}
+	for _, tt := range tests {
+		u, err := ParseULID(tt.s)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ParseULID(%q) = %v, want %v", tt.s, err, tt.want)
+			continue
+		}
+		if got := ULID(tt.s); got != (tt.want == nil) {
+			t.Errorf("ULID(%q) = %v, want %v", tt.s, got, tt.want == nil)
+		}
+		if err != nil {
+			continue
+		}
+		if u.String() != tt.canonical {
+			t.Errorf("ParseULID(%q).String() = %s, want %s", tt.s, u, tt.canonical)
+		}
+		if u.Timestamp() != tt.timestamp {
+			t.Errorf("ParseULID(%q).Timestamp() = %d, want %d", tt.s, u.Timestamp(), tt.timestamp)
+		}
+	}
+}
+
+func TestULIDTime(t *testing.T) {
+	u, _ := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if got := u.Time(); !got.Equal(time.UnixMilli(1469922850259)) || got.Location() != time.UTC {
+		t.Errorf("Time() = %v", got)
+	}
+	u, _ = ParseULID("00000000000000000000000000")
+	if got := u.Time(); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("Time() = %v, want Unix epoch", got)
+	}
+	// 48 位时间戳的上限，约为 10889 年
+	u, _ = ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	if got := u.Time(); got.Year() != 10889 || got.UnixMilli() != 1<<48-1 {
+		t.Errorf("Time() = %v, want max 48-bit timestamp", got)
+	}
+}
+
+func TestCheckULID(t *testing.T) {
+	// 01ARZ3NDEKTSV4RRFFQ69G5FAV 的时间戳为 2016-07-30T23:54:10.259Z
+	const id = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	ts := time.UnixMilli(1469922850259)
+	at := func(t time.Time) func() time.Time { return func() time.Time { return t } }
+	tests := []struct {
+		s    string
+		opts ULIDOptions
+		want error
+	}{
+		{id, ULIDOptions{}, nil},
+		{id, ULIDOptions{NotFuture: true, Now: at(ts)}, nil},
+		{id, ULIDOptions{NotFuture: true, Now: at(ts.Add(-time.Millisecond))}, ErrULIDFuture},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", ULIDOptions{NotFuture: true}, ErrULIDFuture},
+		{id, ULIDOptions{MaxAge: time.Hour, Now: at(ts.Add(time.Hour))}, nil},
+		{id, ULIDOptions{MaxAge: time.Hour, Now: at(ts.Add(time.Hour + time.Millisecond))}, ErrULIDTooOld},
+		{"00000000000000000000000000", ULIDOptions{MaxAge: 24 * time.Hour}, ErrULIDTooOld},
+		{"00000000000000000000000000", ULIDOptions{NotFuture: true}, nil},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", ULIDOptions{}, ErrULIDOverflow},
+	}
+	for _, tt := range tests {
+		if err := CheckULID(tt.s, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckULID(%q, %+v) = %v, want %v", tt.s, tt.opts, err, tt.want)
+		}
+	}
+}
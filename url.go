@@ -0,0 +1,158 @@
+package is
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrURLEmpty    = errors.New("url: empty")
+	ErrURLTooLong  = errors.New("url: too long")
+	ErrURLSyntax   = errors.New("url: invalid syntax")
+	ErrURLRelative = errors.New("url: relative reference not allowed")
+	ErrURLOpaque   = errors.New("url: opaque form not allowed")
+	ErrURLScheme   = errors.New("url: scheme not allowed")
+	ErrURLNotHTTPS = errors.New("url: https required")
+	ErrURLHost     = errors.New("url: invalid or missing host")
+	ErrURLTLD      = errors.New("url: top-level domain required")
+	ErrURLUserinfo = errors.New("url: userinfo not allowed")
+	ErrURLPort     = errors.New("url: port not allowed")
+)
+
+// specialSchemes 是 WHATWG URL 规范中必须包含主机的协议（file 除外）
+var specialSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+	"ws":    true,
+	"wss":   true,
+	"ftp":   true,
+}
+
+// URLOptions 定义 CheckURL 的校验策略，零值只要求给出的字符串是一个绝对 URL。
+type URLOptions struct {
+	// Schemes 允许的协议，不区分大小写，为空时不限制
+	Schemes []string
+	// RequireHost 要求 URL 必须包含主机
+	RequireHost bool
+	// RequireTLD 要求主机为包含顶级域名的域名，IP 地址也不满足要求
+	RequireTLD bool
+	// DenyUserinfo 拒绝 "user:pass@host" 形式的用户信息
+	DenyUserinfo bool
+	// Ports 允许显式指定的端口，为空时不限制，未显式指定端口的 URL 不受影响
+	Ports []int
+	// MaxLength 允许的最大长度，为 0 时不限制
+	MaxLength int
+	// RequireHTTPS 要求协议为 https
+	RequireHTTPS bool
+	// AllowRelative 允许 "/path?q" 这样的相对引用
+	AllowRelative bool
+	// URI 使用 RFC 3986 的 URI 语义：允许 "mailto:a@b.c"、"urn:isbn:123" 这样的不透明形式，
+	// 且不对主机名的字符做额外限制。默认使用 URL 语义，要求层级形式且主机为有效的域名或 IP 地址，
+	// http、https、ws、wss、ftp 协议必须包含主机。
+	URI bool
+}
+
+// CheckURL 按照给出的策略校验 URL，并返回具体的失败原因
+func CheckURL(s string, opts URLOptions) error {
+	_, err := checkURL(s, opts)
+	return err
+}
+
+func checkURL(s string, opts URLOptions) (*url.URL, error) {
+	if s == "" {
+		return nil, ErrURLEmpty
+	}
+	if opts.MaxLength > 0 && len(s) > opts.MaxLength {
+		return nil, ErrURLTooLong
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, ErrURLSyntax
+	}
+	if u.Scheme == "" {
+		if !opts.AllowRelative {
+			return nil, ErrURLRelative
+		}
+	} else {
+		if len(opts.Schemes) > 0 && !containsFold(opts.Schemes, u.Scheme) {
+			return nil, ErrURLScheme
+		}
+		if opts.RequireHTTPS && u.Scheme != "https" {
+			return nil, ErrURLNotHTTPS
+		}
+		if u.Opaque != "" && !opts.URI {
+			return nil, ErrURLOpaque
+		}
+	}
+	host := u.Hostname()
+	if host == "" {
+		if opts.RequireHost || opts.RequireTLD || u.Host != "" || (!opts.URI && specialSchemes[u.Scheme]) {
+			return nil, ErrURLHost
+		}
+	} else {
+		// IP 字面量可以带有 RFC 6874 的区域标识，如 "[fe80::1%25eth0]"
+		if _, err := netip.ParseAddr(host); err != nil && !opts.URI && !Hostname(host) {
+			return nil, ErrURLHost
+		}
+		if opts.RequireTLD && !hasTLD(host) {
+			return nil, ErrURLTLD
+		}
+	}
+	if opts.DenyUserinfo && u.User != nil {
+		return nil, ErrURLUserinfo
+	}
+	if p := u.Port(); p != "" {
+		port := toPort(p)
+		if port <= 0 {
+			return nil, ErrURLPort
+		}
+		if len(opts.Ports) > 0 && !slices.Contains(opts.Ports, port) {
+			return nil, ErrURLPort
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return nil, ErrURLPort
+	}
+	return u, nil
+}
+
+// URI 判断给出的字符串是否为符合 RFC 3986 规范的绝对 URI
+func URI(s string) bool {
+	return CheckURL(s, URLOptions{URI: true}) == nil
+}
+
+// HTTPURL 判断给出的字符串是否为包含主机的 http 或 https URL
+func HTTPURL(s string) bool {
+	return CheckURL(s, URLOptions{Schemes: []string{"http", "https"}, RequireHost: true}) == nil
+}
+
+// hasTLD 判断主机名是否包含由字母组成的顶级域名
+func hasTLD(host string) bool {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return false
+	}
+	host = strings.TrimSuffix(host, ".")
+	i := strings.LastIndexByte(host, '.')
+	if i <= 0 {
+		return false
+	}
+	tld := host[i+1:]
+	if len(tld) < 2 {
+		return false
+	}
+	if strings.HasPrefix(tld, "xn--") {
+		return true
+	}
+	return Alpha(tld)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -101,16 +102,9 @@ func toInt64(in any) (i64 int64, err error) {
 	return
 }
 
-// compString compare string, returns the first op second
+// compString compare string, returns the first op second.
 func compString(first, second, op string) bool {
-	rs := strings.Compare(first, second)
-	if rs < 0 {
-		return op == "<" || op == "<="
-	} else if rs > 0 {
-		return op == ">" || op == ">="
-	} else {
-		return op == ">=" || op == "<=" || op == "="
-	}
+	return compNum(int64(strings.Compare(first, second)), 0, op)
 }
 
 func compTime(first, dstTime time.Time, op string) (ok bool) {
@@ -161,6 +155,15 @@ func boolToInt(a bool) int64 {
 	}
 }
 
+// parseDecimal 解析十进制数，允许指数形式，但不接受 Inf、NaN、十六进制与下划线
+func parseDecimal(s string) (float64, bool) {
+	if !decimalRegex.MatchString(s) {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil && !math.IsInf(v, 0)
+}
+
 // get reflect value length
 func calcLength(val any) int {
 	v := reflect.Indirect(reflect.ValueOf(val))
@@ -0,0 +1,201 @@
+package is
+
+import (
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadUUID     = errors.New("uuid: invalid format")
+	ErrUUIDVersion = errors.New("uuid: version not allowed")
+	ErrUUIDVariant = errors.New("uuid: variant not allowed")
+	ErrUUIDFuture  = errors.New("uuid: timestamp is in the future")
+)
+
+// UUID 变体，取自第 8 个字节的高位
+const (
+	UUIDVariantNCS       = iota // 0xx，NCS 向后兼容
+	UUIDVariantRFC9562          // 10x，RFC 9562（RFC 4122）
+	UUIDVariantMicrosoft        // 110，微软 GUID 向后兼容
+	UUIDVariantFuture           // 111，保留
+)
+
+// 1582-10-15（格里高利历启用日）至 1970-01-01 之间的 100 纳秒间隔数
+const gregorianToUnix100ns = 122192928000000000
+
+// ParsedUUID 是解析后的 UUID
+type ParsedUUID [16]byte
+
+// UUIDOptions 定义 ParseUUID 接受的 UUID 格式，零值只接受小写、带连字符的
+// RFC 9562 变体 UUID（版本 1 至 8）。
+type UUIDOptions struct {
+	// Versions 允许的版本号，为空时允许 1 至 8
+	Versions []int
+	// AllowNil 允许全零的 Nil UUID
+	AllowNil bool
+	// AllowMax 允许全 f 的 Max UUID
+	AllowMax bool
+	// AnyVariant 不检查变体，默认只接受 RFC 9562 变体
+	AnyVariant bool
+	// IgnoreCase 允许大写的十六进制字符
+	IgnoreCase bool
+	// AllowCompact 允许不带连字符的 32 位十六进制形式
+	AllowCompact bool
+	// AllowBraces 允许 "{...}" 包裹的形式
+	AllowBraces bool
+	// AllowURN 允许 "urn:uuid:" 前缀
+	AllowURN bool
+	// NotFuture 拒绝嵌入的时间戳晚于当前时间的 UUID，只对版本 1、6、7 生效
+	NotFuture bool
+	// Now 返回当前时间，为空时使用 time.Now
+	Now func() time.Time
+}
+
+// ParseUUID 按照给出的选项解析 UUID
+func ParseUUID(s string, opts UUIDOptions) (ParsedUUID, error) {
+	var u ParsedUUID
+	if opts.AllowURN && len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	} else if opts.AllowBraces && len(s) > 2 && s[0] == '{' && s[len(s)-1] == '}' {
+		s = s[1 : len(s)-1]
+	}
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, ErrBadUUID
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+		if !opts.AllowCompact {
+			return u, ErrBadUUID
+		}
+	default:
+		return u, ErrBadUUID
+	}
+	if !opts.IgnoreCase && strings.ToLower(s) != s {
+		return u, ErrBadUUID
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, ErrBadUUID
+	}
+	switch {
+	case u.IsNil():
+		if !opts.AllowNil {
+			return u, ErrUUIDVersion
+		}
+		return u, nil
+	case u.IsMax():
+		if !opts.AllowMax {
+			return u, ErrUUIDVersion
+		}
+		return u, nil
+	}
+	if !opts.AnyVariant && u.Variant() != UUIDVariantRFC9562 {
+		return u, ErrUUIDVariant
+	}
+	v := u.Version()
+	if len(opts.Versions) > 0 {
+		if !slices.Contains(opts.Versions, v) {
+			return u, ErrUUIDVersion
+		}
+	} else if v < 1 || v > 8 {
+		return u, ErrUUIDVersion
+	}
+	if opts.NotFuture {
+		now := time.Now
+		if opts.Now != nil {
+			now = opts.Now
+		}
+		if t, ok := u.Time(); ok && t.After(now()) {
+			return u, ErrUUIDFuture
+		}
+	}
+	return u, nil
+}
+
+// CheckUUID 按照给出的选项校验 UUID，并返回具体的失败原因
+func CheckUUID(s string, opts UUIDOptions) error {
+	_, err := ParseUUID(s, opts)
+	return err
+}
+
+// UUID7 判断给出的字符串是否为有效的 v7 UUID
+func UUID7(str string) bool {
+	return CheckUUID(str, UUIDOptions{Versions: []int{7}}) == nil
+}
+
+// Version 返回 UUID 的版本号
+func (u ParsedUUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant 返回 UUID 的变体，取值为 UUIDVariantNCS 等常量
+func (u ParsedUUID) Variant() int {
+	switch {
+	case u[8]&0x80 == 0:
+		return UUIDVariantNCS
+	case u[8]&0xc0 == 0x80:
+		return UUIDVariantRFC9562
+	case u[8]&0xe0 == 0xc0:
+		return UUIDVariantMicrosoft
+	}
+	return UUIDVariantFuture
+}
+
+// IsNil 判断是否为全零的 Nil UUID
+func (u ParsedUUID) IsNil() bool {
+	return u == ParsedUUID{}
+}
+
+// IsMax 判断是否为全 f 的 Max UUID
+func (u ParsedUUID) IsMax() bool {
+	for _, b := range u {
+		if b != 0xff {
+			return false
+		}
+	}
+	return true
+}
+
+// Time 返回版本 1、6、7 的 UUID 中嵌入的时间戳，其它版本返回 false
+func (u ParsedUUID) Time() (time.Time, bool) {
+	switch u.Version() {
+	case 1:
+		ts := uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+			uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+		return gregorianTime(ts), true
+	case 6:
+		ts := uint64(u[0])<<52 | uint64(u[1])<<44 | uint64(u[2])<<36 | uint64(u[3])<<28 |
+			uint64(u[4])<<20 | uint64(u[5])<<12 | uint64(u[6]&0x0f)<<8 | uint64(u[7])
+		return gregorianTime(ts), true
+	case 7:
+		ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 |
+			uint64(u[4])<<8 | uint64(u[5])
+		return time.UnixMilli(int64(ms)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// String 返回小写、带连字符的标准形式
+func (u ParsedUUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// gregorianTime 将自 1582-10-15 起的 100 纳秒间隔数转换为时间
+func gregorianTime(ts uint64) time.Time {
+	d := int64(ts) - gregorianToUnix100ns
+	return time.Unix(d/1e7, d%1e7*100).UTC()
+}
@@ -0,0 +1,125 @@
+package is
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// RFC 9562 附录 A 和 B 中的测试向量
+var rfc9562Vectors = []struct {
+	s       string
+	version int
+	time    string // 版本 1、6、7 嵌入的时间戳
+}{
+	{"c232ab00-9414-11ec-b3c8-9f6bdeced846", 1, "2022-02-22T19:22:22Z"},
+	{"5df41881-3aed-3515-88a7-2f4a814cf09e", 3, ""},
+	{"919108f7-52d1-4320-9bac-f847db4148a8", 4, ""},
+	{"2ed6657d-e927-568b-95e1-2665a8aea6a2", 5, ""},
+	{"1ec9414c-232a-6b00-b3c8-9f6bdeced846", 6, "2022-02-22T19:22:22Z"},
+	{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7, "2022-02-22T19:22:22Z"},
+	{"2489e9ad-2ee2-8e00-8ec9-32d5f69181c0", 8, ""},
+	{"5c146b14-3c52-8afd-938a-375d0df1fbf6", 8, ""},
+}
+
+func TestParseUUIDVectors(t *testing.T) {
+	for _, tt := range rfc9562Vectors {
+		u, err := ParseUUID(tt.s, UUIDOptions{})
+		if err != nil {
+			t.Errorf("ParseUUID(%q) = %v", tt.s, err)
+			continue
+		}
+		if u.Version() != tt.version || u.Variant() != UUIDVariantRFC9562 {
+			t.Errorf("ParseUUID(%q): version %d variant %d, want %d %d", tt.s, u.Version(), u.Variant(), tt.version, UUIDVariantRFC9562)
+		}
+		if u.String() != tt.s {
+			t.Errorf("ParseUUID(%q).String() = %s", tt.s, u)
+		}
+		ts, ok := u.Time()
+		if tt.time == "" {
+			if ok {
+				t.Errorf("ParseUUID(%q).Time() = %v, want none", tt.s, ts)
+			}
+		} else if !ok || ts.Format(time.RFC3339Nano) != tt.time {
+			t.Errorf("ParseUUID(%q).Time() = %v, %v, want %s", tt.s, ts, ok, tt.time)
+		}
+	}
+}
+
+func TestCheckUUID(t *testing.T) {
+	const v4 = "919108f7-52d1-4320-9bac-f847db4148a8"
+	tests := []struct {
+		s    string
+		opts UUIDOptions
+		want error
+	}{
+		{v4, UUIDOptions{}, nil},
+		{"919108F7-52D1-4320-9BAC-F847DB4148A8", UUIDOptions{}, ErrBadUUID},
+		{"919108F7-52D1-4320-9BAC-F847DB4148A8", UUIDOptions{IgnoreCase: true}, nil},
+		{"{" + v4 + "}", UUIDOptions{}, ErrBadUUID},
+		{"{" + v4 + "}", UUIDOptions{AllowBraces: true}, nil},
+		{"{" + v4, UUIDOptions{AllowBraces: true}, ErrBadUUID},
+		{"urn:uuid:" + v4, UUIDOptions{}, ErrBadUUID},
+		{"urn:uuid:" + v4, UUIDOptions{AllowURN: true}, nil},
+		{"URN:UUID:" + v4, UUIDOptions{AllowURN: true}, nil},
+		{"urn:uuid:{" + v4 + "}", UUIDOptions{AllowURN: true, AllowBraces: true}, ErrBadUUID},
+		{"919108f752d143209bacf847db4148a8", UUIDOptions{}, ErrBadUUID},
+		{"919108f752d143209bacf847db4148a8", UUIDOptions{AllowCompact: true}, nil},
+		{"919108f7-52d14320-9bac-f847db4148a8", UUIDOptions{AllowCompact: true}, ErrBadUUID},
+		{"919108f7_52d1_4320_9bac_f847db4148a8", UUIDOptions{}, ErrBadUUID},
+		{"919108g7-52d1-4320-9bac-f847db4148a8", UUIDOptions{}, ErrBadUUID},
+		{"", UUIDOptions{}, ErrBadUUID},
+
+		{"00000000-0000-0000-0000-000000000000", UUIDOptions{}, ErrUUIDVersion},
+		{"00000000-0000-0000-0000-000000000000", UUIDOptions{AllowNil: true}, nil},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", UUIDOptions{}, ErrUUIDVersion},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", UUIDOptions{AllowMax: true}, nil},
+		{"919108f7-52d1-0320-9bac-f847db4148a8", UUIDOptions{}, ErrUUIDVersion},
+		{"919108f7-52d1-9320-9bac-f847db4148a8", UUIDOptions{}, ErrUUIDVersion},
+		{v4, UUIDOptions{Versions: []int{1, 7}}, ErrUUIDVersion},
+		{"919108f7-52d1-4320-cbac-f847db4148a8", UUIDOptions{}, ErrUUIDVariant},
+		{"919108f7-52d1-4320-cbac-f847db4148a8", UUIDOptions{AnyVariant: true}, nil},
+		{"919108f7-52d1-4320-1bac-f847db4148a8", UUIDOptions{}, ErrUUIDVariant},
+	}
+	for _, tt := range tests {
+		if err := CheckUUID(tt.s, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("CheckUUID(%q, %+v) = %v, want %v", tt.s, tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestCheckUUIDNotFuture(t *testing.T) {
+	// 向量中的时间戳为 2022-02-22T19:22:22Z
+	before := func() time.Time { return time.Date(2022, 2, 22, 19, 22, 21, 0, time.UTC) }
+	after := func() time.Time { return time.Date(2022, 2, 22, 19, 22, 23, 0, time.UTC) }
+	for _, tt := range rfc9562Vectors {
+		err := CheckUUID(tt.s, UUIDOptions{NotFuture: true, Now: before})
+		if tt.time != "" && !errors.Is(err, ErrUUIDFuture) {
+			t.Errorf("CheckUUID(%q) before timestamp = %v, want ErrUUIDFuture", tt.s, err)
+		} else if tt.time == "" && err != nil {
+			t.Errorf("CheckUUID(%q) = %v, want nil for version without timestamp", tt.s, err)
+		}
+		if err := CheckUUID(tt.s, UUIDOptions{NotFuture: true, Now: after}); err != nil {
+			t.Errorf("CheckUUID(%q) after timestamp = %v", tt.s, err)
+		}
+	}
+}
+
+func TestUUID7(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", true},
+		{"017F22E2-79B0-7CC3-98C4-DC0C0C07398F", false},
+		{"1ec9414c-232a-6b00-b3c8-9f6bdeced846", false},
+		{"919108f7-52d1-4320-9bac-f847db4148a8", false},
+		{"017f22e2-79b0-7cc3-c8c4-dc0c0c07398f", false},
+		{"017f22e279b07cc398c4dc0c0c07398f", false},
+	}
+	for _, tt := range tests {
+		if got := UUID7(tt.s); got != tt.want {
+			t.Errorf("UUID7(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
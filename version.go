@@ -0,0 +1,495 @@
+package is
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadVersion    = errors.New("bad version")
+	ErrBadConstraint = errors.New("bad version constraint")
+
+	partialVersionRegex = regexp.MustCompile(`^v?(0|[1-9]\d*|[xX*])(?:\.(0|[1-9]\d*|[xX*]))?(?:\.(0|[1-9]\d*|[xX*]))?(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	hyphenRangeRegex    = regexp.MustCompile(`^\s*(\S+)\s+-\s+(\S+)\s*$`)
+	pseudoVersionRegex  = regexp.MustCompile(`^v[0-9]+\.(?:0\.0-|[0-9]+\.[0-9]+-(?:[^+]*\.)?0\.)[0-9]{14}-[0-9a-f]{12}(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+)
+
+// Version 是符合语义化版本 2.0.0 规范的版本号
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// ParseVersion 解析语义化版本号，语法与 Semver 一致
+func ParseVersion(s string) (Version, error) {
+	m := semverRegex.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, ErrBadVersion
+	}
+	var v Version
+	var err error
+	if v.Major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return Version{}, ErrBadVersion
+	}
+	if v.Minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return Version{}, ErrBadVersion
+	}
+	if v.Patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return Version{}, ErrBadVersion
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+	}
+	if m[5] != "" {
+		v.Build = strings.Split(m[5], ".")
+	}
+	return v, nil
+}
+
+// String 返回版本号的规范字符串形式
+func (v Version) String() string {
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(v.Major, 10))
+	b.WriteByte('.')
+	b.WriteString(strconv.FormatUint(v.Minor, 10))
+	b.WriteByte('.')
+	b.WriteString(strconv.FormatUint(v.Patch, 10))
+	if len(v.Prerelease) > 0 {
+		b.WriteByte('-')
+		b.WriteString(strings.Join(v.Prerelease, "."))
+	}
+	if len(v.Build) > 0 {
+		b.WriteByte('+')
+		b.WriteString(strings.Join(v.Build, "."))
+	}
+	return b.String()
+}
+
+// Compare 按照语义化版本规范比较版本的优先级，v 小于、等于、大于 o 时
+// 分别返回 -1、0、1，构建元数据不参与比较。
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease 比较先行版本号：没有先行版本号的优先级更高；
+// 纯数字的标识符按数值比较且低于包含字母的标识符；其余按 ASCII 顺序比较；
+// 前面的标识符都相同时，标识符较多的优先级更高。
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, errX := strconv.ParseUint(a[i], 10, 64)
+		y, errY := strconv.ParseUint(b[i], 10, 64)
+		var c int
+		switch {
+		case errX == nil && errY == nil:
+			c = compareUint(x, y)
+		case errX == nil:
+			c = -1
+		case errY == nil:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+// CompareVersion 按照语义化版本的优先级比较 a 与 b，返回 `a op b` 的结果，
+// op 可以是 =、!=、<、<=、>、>=。a 与 b 可以是 Version、*Version 或版本号字符串，
+// 任意一方无法解析时返回 false。构建元数据不参与比较。
+func CompareVersion(a, b any, op string) bool {
+	va, ok := toVersion(a)
+	if !ok {
+		return false
+	}
+	vb, ok := toVersion(b)
+	if !ok {
+		return false
+	}
+	return compNum(int64(va.Compare(vb)), 0, op)
+}
+
+// VersionBetween 判断 val 的版本优先级是否在 min 与 max 之间（包括两端），
+// 三者可以是 Version、*Version 或版本号字符串，任意一方无法解析时返回 false。
+// 与 Between 一样，min 大于 max 时会 panic。
+func VersionBetween(val, min, max any) bool {
+	if CompareVersion(min, max, ">") {
+		panic(ErrBadRange)
+	}
+	return CompareVersion(val, min, ">=") && CompareVersion(val, max, "<=")
+}
+
+func isVersion(val any) bool {
+	switch val.(type) {
+	case Version, *Version:
+		return true
+	}
+	return false
+}
+
+// toVersion 将 Version 或版本号字符串转换为 Version
+func toVersion(val any) (Version, bool) {
+	switch v := val.(type) {
+	case Version:
+		return v, true
+	case *Version:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		if ver, err := ParseVersion(v); err == nil {
+			return ver, true
+		}
+	}
+	return Version{}, false
+}
+
+type versionComparator struct {
+	op string
+	v  Version
+}
+
+func (c versionComparator) match(v Version) bool {
+	n := v.Compare(c.v)
+	switch c.op {
+	case "=":
+		return n == 0
+	case "!=":
+		return n != 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	}
+	return false
+}
+
+// Constraint 是版本约束表达式，语法与 npm 的 semver 范围基本一致：
+//
+//   - 比较：=1.2.3、!=1.2.3、>1.2.3、>=1.2、<2、<=1.2.x
+//   - 通配：*、1.x、1.2.*，省略的部分视为通配
+//   - 波浪号：~1.2.3 等价于 >=1.2.3 <1.3.0，~1 等价于 >=1.0.0 <2.0.0
+//   - 插入符：^1.2.3 等价于 >=1.2.3 <2.0.0，^0.2.3 等价于 >=0.2.3 <0.3.0
+//   - 连字符范围：1.2 - 2.3.4 等价于 >=1.2.0 <=2.3.4
+//
+// 以空格或逗号分隔的比较条件需要同时满足，以 "||" 分隔的条件组满足其一即可。
+// 带有先行版本号的版本只有在同一条件组中存在相同主、次、修订号且带有先行版本号的
+// 比较条件时才可能满足约束，例如 1.3.0-beta 满足 >=1.3.0-alpha 但不满足 >=1.2.0。
+type Constraint struct {
+	raw  string
+	sets [][]versionComparator
+}
+
+// ParseConstraint 解析版本约束表达式
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: s}
+	for _, group := range strings.Split(s, "||") {
+		set, err := parseComparatorSet(group)
+		if err != nil {
+			return nil, err
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// String 返回约束表达式的原始字符串
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check 判断给出的版本是否满足约束
+func (c *Constraint) Check(v Version) bool {
+	for _, set := range c.sets {
+		if matchComparatorSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchComparatorSet(set []versionComparator, v Version) bool {
+	for _, c := range set {
+		if !c.match(v) {
+			return false
+		}
+	}
+	if len(v.Prerelease) == 0 {
+		return true
+	}
+	for _, c := range set {
+		if len(c.v.Prerelease) > 0 && c.v.Major == v.Major && c.v.Minor == v.Minor && c.v.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// SemverSatisfies 判断给出的版本号是否满足版本约束表达式，
+// 版本号或约束无效时返回 false。
+func SemverSatisfies(v, constraint string) bool {
+	ver, err := ParseVersion(v)
+	if err != nil {
+		return false
+	}
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	return c.Check(ver)
+}
+
+func parseComparatorSet(s string) ([]versionComparator, error) {
+	if m := hyphenRangeRegex.FindStringSubmatch(s); m != nil {
+		lo, err := expandComparator(">=", m[1])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := expandComparator("<=", m[2])
+		if err != nil {
+			return nil, err
+		}
+		return append(lo, hi...), nil
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	if len(fields) == 0 {
+		// 空的条件组匹配任意正式版本
+		fields = []string{"*"}
+	}
+	var set []versionComparator
+	for i := 0; i < len(fields); i++ {
+		op, ver := splitOperator(fields[i])
+		if ver == "" {
+			// 允许运算符与版本号之间存在空格，如 ">= 1.2"
+			if i+1 >= len(fields) {
+				return nil, ErrBadConstraint
+			}
+			i++
+			ver = fields[i]
+		}
+		cs, err := expandComparator(op, ver)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cs...)
+	}
+	return set, nil
+}
+
+func splitOperator(s string) (op, ver string) {
+	for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"} {
+		if v, ok := strings.CutPrefix(s, op); ok {
+			if op == "==" {
+				op = "="
+			}
+			return op, v
+		}
+	}
+	return "", s
+}
+
+// partialVersion 是约束中可能省略部分字段的版本号，省略或通配的字段为 -1
+type partialVersion struct {
+	major, minor, patch int64
+	pre                 []string
+}
+
+func parsePartialVersion(s string) (partialVersion, error) {
+	m := partialVersionRegex.FindStringSubmatch(s)
+	if m == nil {
+		return partialVersion{}, ErrBadConstraint
+	}
+	p := partialVersion{major: -1, minor: -1, patch: -1}
+	fields := []*int64{&p.major, &p.minor, &p.patch}
+	for i, f := range m[1:4] {
+		if f == "" || f == "x" || f == "X" || f == "*" {
+			break
+		}
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return partialVersion{}, ErrBadConstraint
+		}
+		*fields[i] = n
+	}
+	if m[4] != "" {
+		if p.patch < 0 {
+			// 先行版本号只能出现在完整的版本号中
+			return partialVersion{}, ErrBadConstraint
+		}
+		p.pre = strings.Split(m[4], ".")
+	}
+	return p, nil
+}
+
+// floor 返回部分版本号所表示范围的下界，省略的字段补零
+func (p partialVersion) floor() Version {
+	v := Version{Prerelease: p.pre}
+	if p.major > 0 {
+		v.Major = uint64(p.major)
+	}
+	if p.minor > 0 {
+		v.Minor = uint64(p.minor)
+	}
+	if p.patch > 0 {
+		v.Patch = uint64(p.patch)
+	}
+	return v
+}
+
+// ceil 返回部分版本号所表示范围的上界（不含），例如 1.2 的上界为 1.3.0-0
+func (p partialVersion) ceil() Version {
+	if p.minor < 0 {
+		return versionFloor(uint64(p.major)+1, 0, 0)
+	}
+	return versionFloor(uint64(p.major), uint64(p.minor)+1, 0)
+}
+
+// versionFloor 返回给定主、次、修订号下优先级最低的版本，即 x.y.z-0
+func versionFloor(major, minor, patch uint64) Version {
+	return Version{Major: major, Minor: minor, Patch: patch, Prerelease: []string{"0"}}
+}
+
+func expandComparator(op, s string) ([]versionComparator, error) {
+	p, err := parsePartialVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	full := p.patch >= 0
+	if p.major < 0 {
+		if op == ">" || op == "<" || op == "!=" {
+			// 没有任何版本大于或小于所有版本
+			return []versionComparator{{"<", Version{Prerelease: []string{"0"}}}}, nil
+		}
+		return []versionComparator{{">=", Version{}}}, nil
+	}
+	lo := p.floor()
+	switch op {
+	case "", "=":
+		if full {
+			return []versionComparator{{"=", lo}}, nil
+		}
+		return []versionComparator{{">=", lo}, {"<", p.ceil()}}, nil
+	case "!=":
+		if !full {
+			return nil, ErrBadConstraint
+		}
+		return []versionComparator{{"!=", lo}}, nil
+	case ">":
+		if full {
+			return []versionComparator{{">", lo}}, nil
+		}
+		hi := p.ceil()
+		hi.Prerelease = nil
+		return []versionComparator{{">=", hi}}, nil
+	case ">=":
+		return []versionComparator{{">=", lo}}, nil
+	case "<":
+		if full {
+			return []versionComparator{{"<", lo}}, nil
+		}
+		return []versionComparator{{"<", versionFloor(lo.Major, lo.Minor, lo.Patch)}}, nil
+	case "<=":
+		if full {
+			return []versionComparator{{"<=", lo}}, nil
+		}
+		return []versionComparator{{"<", p.ceil()}}, nil
+	case "~":
+		return []versionComparator{{">=", lo}, {"<", p.ceil()}}, nil
+	case "^":
+		var hi Version
+		switch {
+		case p.major > 0 || p.minor < 0:
+			hi = versionFloor(uint64(p.major)+1, 0, 0)
+		case p.minor > 0 || p.patch < 0:
+			hi = versionFloor(0, uint64(p.minor)+1, 0)
+		default:
+			hi = versionFloor(0, 0, uint64(p.patch)+1)
+		}
+		return []versionComparator{{">=", lo}, {"<", hi}}, nil
+	}
+	return nil, ErrBadConstraint
+}
+
+// ParseSemverTag 解析可能带有 "v" 前缀的语义化版本号，如 Git 标签 "v1.2.3"
+func ParseSemverTag(s string) (Version, error) {
+	if len(s) > 0 && (s[0] == 'v' || s[0] == 'V') {
+		s = s[1:]
+	}
+	return ParseVersion(s)
+}
+
+// SemverTag 判断给出的字符串是否为可能带有 "v" 前缀的语义化版本号
+func SemverTag(s string) bool {
+	_, err := ParseSemverTag(s)
+	return err == nil
+}
+
+// ParsePseudoVersion 解析 Go 模块的伪版本号，返回版本号、提交时间（UTC）和修订号前缀。
+// 伪版本号有以下三种形式：
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef     没有可参照的标签
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef 参照先行版本标签 vX.Y.Z-pre
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef 参照正式版本标签 vX.Y.Z
+//
+// 伪版本号本身也是语义化版本号，可以直接使用 Version.Compare 排序。
+func ParsePseudoVersion(s string) (v Version, t time.Time, rev string, err error) {
+	if !pseudoVersionRegex.MatchString(s) {
+		return Version{}, time.Time{}, "", ErrBadVersion
+	}
+	if v, err = ParseSemverTag(s); err != nil {
+		return Version{}, time.Time{}, "", err
+	}
+	// 最后一个先行版本标识符由时间戳和修订号组成，如 "20240108093000-abcdef123456"
+	stamp, rev, _ := strings.Cut(v.Prerelease[len(v.Prerelease)-1], "-")
+	if t, err = time.Parse("20060102150405", stamp); err != nil {
+		return Version{}, time.Time{}, "", ErrBadVersion
+	}
+	return v, t, rev, nil
+}
+
+// PseudoVersion 判断给出的字符串是否为 Go 模块的伪版本号
+func PseudoVersion(s string) bool {
+	_, _, _, err := ParsePseudoVersion(s)
+	return err == nil
+}
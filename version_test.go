@@ -0,0 +1,194 @@
+package is
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareVersion(t *testing.T) {
+	v1 := Version{Major: 1}
+	v2 := Version{Major: 2}
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{`Compare("2.0.0", v1, ">")`, Compare("2.0.0", v1, ">"), true},
+		{`Compare(v1, "2.0.0", "<")`, Compare(v1, "2.0.0", "<"), true},
+		{`Compare(&v2, v1, ">=")`, Compare(&v2, v1, ">="), true},
+		{`Compare(v1, "1.0.0", "=")`, Compare(v1, "1.0.0", "="), true},
+		{`Compare(v1, "1.0.0", "!=")`, Compare(v1, "1.0.0", "!="), false},
+		{`Compare(v1, "bogus", "=")`, Compare(v1, "bogus", "="), false},
+		{`Compare(v1, 1, "<")`, Compare(v1, 1, "<"), false},
+		{`Between("1.5.0", v1, v2)`, Between("1.5.0", v1, v2), true},
+		{`Between("1.10.0", v1, v2)`, Between("1.10.0", v1, v2), true},
+		{`Between("2.0.1", v1, v2)`, Between("2.0.1", v1, v2), false},
+		{`NotBetween("1.0.0-rc.1", v1, v2)`, NotBetween("1.0.0-rc.1", v1, v2), true},
+		{`NotBetween("2.0.0", v1, v2)`, NotBetween("2.0.0", v1, v2), false},
+
+		// 普通字符串按字典序比较，= 与 != 为精确比较
+		{`Equal("1.0.0+a", "1.0.0+b")`, Equal("1.0.0+a", "1.0.0+b"), false},
+		{`Equal("v1", "v1")`, Equal("v1", "v1"), true},
+		{`GreaterThan("1.10.0", "1.9.0")`, GreaterThan("1.10.0", "1.9.0"), false},
+		{`GreaterThan("b", "a")`, GreaterThan("b", "a"), true},
+
+		{`Compare("1.10.0", "1.9.0", ">")`, Compare("1.10.0", "1.9.0", ">"), false},
+		{`Between("1.5.0", "1.2.0", v2)`, Between("1.5.0", "1.2.0", v2), true},
+		{`Between("1.10.0", v1, Version{Major: 1, Minor: 9})`, Between("1.10.0", v1, Version{Major: 1, Minor: 9}), false},
+		{`VersionBetween("1.5.0", "1.2.0", "1.10.0")`, VersionBetween("1.5.0", "1.2.0", "1.10.0"), true},
+		{`VersionBetween("1.10.0", "1.2.0", "1.9.0")`, VersionBetween("1.10.0", "1.2.0", "1.9.0"), false},
+		{`VersionBetween("1.2.0-rc.1", "1.2.0", "1.10.0")`, VersionBetween("1.2.0-rc.1", "1.2.0", "1.10.0"), false},
+		{`VersionBetween("bogus", "1.2.0", "1.10.0")`, VersionBetween("bogus", "1.2.0", "1.10.0"), false},
+		{`CompareVersion("1.10.0", "1.9.0", ">")`, CompareVersion("1.10.0", "1.9.0", ">"), true},
+		{`CompareVersion("1.0.0-alpha", "1.0.0", "<")`, CompareVersion("1.0.0-alpha", "1.0.0", "<"), true},
+		{`CompareVersion("1.0.0-alpha.1", "1.0.0-alpha.beta", "<")`, CompareVersion("1.0.0-alpha.1", "1.0.0-alpha.beta", "<"), true},
+		{`CompareVersion("1.0.0-rc.2", "1.0.0-rc.10", "<")`, CompareVersion("1.0.0-rc.2", "1.0.0-rc.10", "<"), true},
+		{`CompareVersion("1.0.0+a", "1.0.0+b", "=")`, CompareVersion("1.0.0+a", "1.0.0+b", "="), true},
+		{`CompareVersion("1.0", "1.0.0", "=")`, CompareVersion("1.0", "1.0.0", "="), false},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSemverSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		want                bool
+	}{
+		{"1.2.3", "^1.2", true},
+		{"2.0.0", "^1.2", false},
+		{"0.2.5", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.5.0", ">=1.0 <2.0 || 3.x", true},
+		{"3.4.1", ">=1.0 <2.0 || 3.x", true},
+		{"2.1.0", ">=1.0 <2.0 || 3.x", false},
+		{"1.2.0-beta", "^1.1", false},
+		{"1.5.0", "1.2 - 1.8", true},
+		{"bogus", "*", false},
+		{"1.0.0", ">>1", false},
+	}
+	for _, tt := range tests {
+		if got := SemverSatisfies(tt.version, tt.constraint); got != tt.want {
+			t.Errorf("SemverSatisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestVersionBetweenBadRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrBadRange {
+			t.Errorf("VersionBetween with min > max: recovered %v, want %v", r, ErrBadRange)
+		}
+	}()
+	VersionBetween("1.5.0", "1.10.0", "1.2.0")
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string // 为空表示应当解析失败
+	}{
+		{"1.2.3", "1.2.3"},
+		{"0.0.0", "0.0.0"},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5"},
+		{"1.0.0-alpha-beta.0.x-y", "1.0.0-alpha-beta.0.x-y"},
+		{"1.0.0+001", "1.0.0+001"},
+		{"01.2.3", ""},
+		{"1.2.3-01", ""},
+		{"1.2", ""},
+		{"v1.2.3", ""},
+		{"1.2.3-", ""},
+		{"1.2.3+", ""},
+		{"1.2.3-a..b", ""},
+		{"99999999999999999999.0.0", ""},
+	}
+	for _, tt := range tests {
+		v, err := ParseVersion(tt.s)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseVersion(%q) = %s, want error", tt.s, v)
+			}
+			continue
+		}
+		if err != nil || v.String() != tt.want {
+			t.Errorf("ParseVersion(%q) = %s, %v, want %s", tt.s, v, err, tt.want)
+		}
+	}
+}
+
+func TestParseSemverTag(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"v1.2.3", "1.2.3"},
+		{"V1.2.3-rc.1", "1.2.3-rc.1"},
+		{"1.2.3", "1.2.3"},
+		{"vv1.2.3", ""},
+		{"v1.2", ""},
+		{"v", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		v, err := ParseSemverTag(tt.s)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseSemverTag(%q) = %s, want error", tt.s, v)
+			}
+			continue
+		}
+		if err != nil || v.String() != tt.want {
+			t.Errorf("ParseSemverTag(%q) = %s, %v, want %s", tt.s, v, err, tt.want)
+		}
+	}
+}
+
+func TestParsePseudoVersion(t *testing.T) {
+	tests := []struct {
+		s       string
+		version string // 为空表示应当解析失败
+		time    string
+		rev     string
+	}{
+		{"v0.0.0-20240108093000-abcdef123456", "0.0.0-20240108093000-abcdef123456", "2024-01-08T09:30:00Z", "abcdef123456"},
+		{"v1.2.4-0.20240108093000-abcdef123456", "1.2.4-0.20240108093000-abcdef123456", "2024-01-08T09:30:00Z", "abcdef123456"},
+		{"v1.2.3-pre.0.20191231235959-0123456789ab", "1.2.3-pre.0.20191231235959-0123456789ab", "2019-12-31T23:59:59Z", "0123456789ab"},
+		{"v2.0.0-20240108093000-abcdef123456+incompatible", "2.0.0-20240108093000-abcdef123456+incompatible", "2024-01-08T09:30:00Z", "abcdef123456"},
+
+		{"v1.2.3-20240108093000-abcdef123456", "", "", ""},
+		{"v0.0.0-20241308093000-abcdef123456", "", "", ""},
+		{"v0.0.0-20240108093000-abcdef12345", "", "", ""},
+		{"v0.0.0-20240108093000-ABCDEF123456", "", "", ""},
+		{"0.0.0-20240108093000-abcdef123456", "", "", ""},
+		{"v1.2.3", "", "", ""},
+	}
+	for _, tt := range tests {
+		v, ts, rev, err := ParsePseudoVersion(tt.s)
+		if tt.version == "" {
+			if err == nil {
+				t.Errorf("ParsePseudoVersion(%q) = %s, want error", tt.s, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePseudoVersion(%q) = %v", tt.s, err)
+			continue
+		}
+		if v.String() != tt.version || ts.Format(time.RFC3339) != tt.time || rev != tt.rev {
+			t.Errorf("ParsePseudoVersion(%q) = %s, %s, %s, want %s, %s, %s", tt.s, v, ts.Format(time.RFC3339), rev, tt.version, tt.time, tt.rev)
+		}
+	}
+
+	// 伪版本号排在参照的标签之后、下一个正式版本之前
+	base, _ := ParseVersion("1.2.3")
+	pseudo, _, _, _ := ParsePseudoVersion("v1.2.4-0.20240108093000-abcdef123456")
+	next, _ := ParseVersion("1.2.4")
+	if base.Compare(pseudo) != -1 || pseudo.Compare(next) != -1 {
+		t.Error("pseudo-version should sort between v1.2.3 and v1.2.4")
+	}
+}
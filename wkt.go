@@ -0,0 +1,218 @@
+package is
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var ErrBadWKT = errors.New("wkt: invalid geometry")
+
+// CheckWKT 校验 WKT（Well-Known Text）几何字符串，支持 POINT、LINESTRING、POLYGON、
+// MULTIPOINT、MULTILINESTRING、MULTIPOLYGON 与 GEOMETRYCOLLECTION，类型名不区分大小写，
+// 可以带有 Z、M、ZM 维度标记、EMPTY，以及 PostGIS EWKT 的 "SRID=4326;" 前缀。
+//
+// 同一几何对象中所有坐标的维数必须一致，GEOMETRYCOLLECTION 中的各个几何对象也是如此；
+// 多边形的环必须闭合且至少有四个点。
+// WKT 可能使用投影坐标系，因此不检查坐标的取值范围。
+func CheckWKT(s string) error {
+	p := &wktParser{s: s}
+	if rest, ok := cutPrefixFold(s, "SRID="); ok {
+		srid, geom, ok := strings.Cut(rest, ";")
+		if !ok || !Number(srid) {
+			return ErrBadWKT
+		}
+		p.s = geom
+	}
+	if !p.geometry() {
+		return ErrBadWKT
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return ErrBadWKT
+	}
+	return nil
+}
+
+// WKT 判断给出的字符串是否为有效的 WKT 几何字符串，参见 CheckWKT
+func WKT(s string) bool {
+	return CheckWKT(s) == nil
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+type wktParser struct {
+	s    string
+	pos  int
+	dims int // 坐标维数，0 表示尚未确定
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// word 读取一个由字母组成的单词并转换为大写
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos]|0x20 >= 'a' && p.s[p.pos]|0x20 <= 'z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+// peekWord 读取下一个单词但不移动位置
+func (p *wktParser) peekWord() string {
+	pos := p.pos
+	w := p.word()
+	p.pos = pos
+	return w
+}
+
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) geometry() bool {
+	typ := p.word()
+	dims := 0
+	switch p.peekWord() {
+	case "Z", "M":
+		p.word()
+		dims = 3
+	case "ZM":
+		p.word()
+		dims = 4
+	}
+	// 维数标记需要与集合或之前的几何对象确定的维数一致
+	if dims != 0 {
+		if p.dims != 0 && p.dims != dims {
+			return false
+		}
+		p.dims = dims
+	}
+	if p.peekWord() == "EMPTY" {
+		p.word()
+		return isWKTType(typ)
+	}
+	switch typ {
+	case "POINT":
+		return p.consume('(') && p.coordinate() != nil && p.consume(')')
+	case "LINESTRING":
+		return p.lineString(2)
+	case "POLYGON":
+		return p.polygon()
+	case "MULTIPOINT":
+		// 点可以带括号，也可以不带括号
+		return p.list(func() bool {
+			if p.consume('(') {
+				return p.coordinate() != nil && p.consume(')')
+			}
+			return p.coordinate() != nil
+		})
+	case "MULTILINESTRING":
+		return p.list(func() bool { return p.lineString(2) })
+	case "MULTIPOLYGON":
+		return p.list(p.polygon)
+	case "GEOMETRYCOLLECTION":
+		return p.list(p.geometry)
+	}
+	return false
+}
+
+func isWKTType(typ string) bool {
+	switch typ {
+	case "POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION":
+		return true
+	}
+	return false
+}
+
+// list 解析以括号包围、逗号分隔的元素列表，元素可以是 EMPTY
+func (p *wktParser) list(elem func() bool) bool {
+	if !p.consume('(') {
+		return false
+	}
+	for {
+		if p.peekWord() == "EMPTY" {
+			p.word()
+		} else if !elem() {
+			return false
+		}
+		if !p.consume(',') {
+			return p.consume(')')
+		}
+	}
+}
+
+// lineString 解析坐标序列，至少包含 minPoints 个点
+func (p *wktParser) lineString(minPoints int) bool {
+	_, ok := p.points(minPoints)
+	return ok
+}
+
+func (p *wktParser) points(minPoints int) ([][]float64, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var points [][]float64
+	for {
+		c := p.coordinate()
+		if c == nil {
+			return nil, false
+		}
+		points = append(points, c)
+		if !p.consume(',') {
+			return points, p.consume(')') && len(points) >= minPoints
+		}
+	}
+}
+
+func (p *wktParser) polygon() bool {
+	return p.list(func() bool {
+		ring, ok := p.points(4)
+		return ok && slices.Equal(ring[0], ring[len(ring)-1])
+	})
+}
+
+// coordinate 解析由空白分隔的 2 至 4 个数值组成的坐标，无效时返回 nil
+func (p *wktParser) coordinate() []float64 {
+	var c []float64
+	for {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.pos]) >= 0 {
+			p.pos++
+		}
+		if start == p.pos {
+			break
+		}
+		v, ok := parseDecimal(p.s[start:p.pos])
+		if !ok {
+			return nil
+		}
+		c = append(c, v)
+	}
+	if len(c) < 2 || len(c) > 4 {
+		return nil
+	}
+	if p.dims == 0 {
+		p.dims = len(c)
+	}
+	if len(c) != p.dims {
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,79 @@
+package is
+
+import "testing"
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"POINT (30 10)", true},
+		{"point(30 10)", true},
+		{"POINT Z (30 10 5)", true},
+		{"POINT ZM (30 10 5 1)", true},
+		{"POINT M (30 10 1)", true},
+		{"POINT EMPTY", true},
+		{"POINT Z EMPTY", true},
+		{"POINT (30)", false},
+		{"POINT (1 2 3 4 5)", false},
+		{"POINT Z (30 10)", false},
+		{"POINT ZM (30 10 5)", false},
+		{"POINT (30 10", false},
+		{"POINT (30 10) extra", false},
+		{"POINT (1e3 -2.5)", true},
+		{"POINT (1e 2)", false},
+		{"CIRCLE (0 0)", false},
+		{"", false},
+
+		{"LINESTRING (30 10, 10 30, 40 40)", true},
+		{"LINESTRING (30 10)", false},
+		// 同一几何对象中的坐标维数必须一致
+		{"LINESTRING (30 10, 10 30 5)", false},
+		{"LINESTRING Z (30 10 1, 10 30 2)", true},
+
+		// 多边形的环必须闭合且至少有四个点
+		{"POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10))", true},
+		{"POLYGON ((35 10, 45 45, 15 40, 10 20, 35 10), (20 30, 35 35, 30 20, 20 30))", true},
+		{"POLYGON ((30 10, 40 40, 20 40, 10 20))", false},
+		{"POLYGON ((30 10, 40 40, 30 10))", false},
+		{"POLYGON Z ((0 0 1, 1 0 1, 1 1 1, 0 0 2))", false},
+		{"POLYGON Z ((0 0 1, 1 0 1, 1 1 1, 0 0 1))", true},
+		{"POLYGON ((0 0, 1 0, 1 1, 0 0), (0 0 0, 1 0 0, 1 1 0, 0 0 0))", false},
+
+		{"MULTIPOINT ((10 40), (40 30), (20 20))", true},
+		{"MULTIPOINT (10 40, 40 30, 20 20)", true},
+		{"MULTIPOINT (10 40, 40 30 1)", false},
+		{"MULTIPOINT ((10 40), EMPTY)", true},
+		{"MULTILINESTRING ((10 10, 20 20), (40 40, 30 30, 40 20))", true},
+		{"MULTILINESTRING ((10 10, 20 20), (40 40))", false},
+		{"MULTIPOLYGON (((30 20, 45 40, 10 40, 30 20)), ((15 5, 40 10, 10 20, 5 10, 15 5)))", true},
+		{"MULTIPOLYGON (((30 20, 45 40, 10 40, 30 20)), ((15 5, 40 10, 10 20)))", false},
+
+		// GEOMETRYCOLLECTION 可以嵌套，其中的几何对象维数也必须一致
+		{"GEOMETRYCOLLECTION (POINT (40 10), LINESTRING (10 10, 20 20, 10 40))", true},
+		{"GEOMETRYCOLLECTION (POINT (40 10), GEOMETRYCOLLECTION (POINT (1 2), POLYGON ((0 0, 1 0, 1 1, 0 0))))", true},
+		{"GEOMETRYCOLLECTION (POINT (40 10), GEOMETRYCOLLECTION EMPTY, EMPTY)", true},
+		{"GEOMETRYCOLLECTION EMPTY", true},
+		{"GEOMETRYCOLLECTION (POINT (40 10), POINT (1 2 3))", false},
+		{"GEOMETRYCOLLECTION (POINT (40 10), GEOMETRYCOLLECTION (POINT Z (1 2 3)))", false},
+		{"GEOMETRYCOLLECTION Z (POINT (1 2 3), POINT Z (4 5 6))", true},
+		{"GEOMETRYCOLLECTION Z (POINT (1 2))", false},
+		{"GEOMETRYCOLLECTION ZM (POINT Z (1 2 3))", false},
+		{"GEOMETRYCOLLECTION (POINT (40 10), CIRCLE (0 0))", false},
+		{"GEOMETRYCOLLECTION (POINT (40 10)", false},
+
+		// PostGIS EWKT 的 SRID 前缀
+		{"SRID=4326;POINT (30 10)", true},
+		{"srid=3857;POINT (3339584.72 1118889.97)", true},
+		{"SRID=4326;", false},
+		{"SRID=;POINT (30 10)", false},
+		{"SRID=abc;POINT (30 10)", false},
+		{"SRID=-1;POINT (30 10)", false},
+		{"SRID=4326 POINT (30 10)", false},
+	}
+	for _, tt := range tests {
+		if got := WKT(tt.s); got != tt.want {
+			t.Errorf("WKT(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}